@@ -3,6 +3,7 @@ package ansi
 const (
 	Reset      = "\033[0m"
 	Bold       = "\033[1m"
+	Underline  = "\033[4m"
 	Red        = "\033[38;2;255;0;102m"
 	Green      = "\033[38;2;0;255;136m"
 	Yellow     = "\033[38;2;255;238;0m"
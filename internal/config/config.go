@@ -31,6 +31,14 @@ const (
 	ColorSchemeHighContrast ColorScheme = "high-contrast"
 )
 
+// ScriptOrder defines how scripts are ordered in the menu.
+type ScriptOrder string
+
+const (
+	ScriptOrderRecent ScriptOrder = "recent"
+	ScriptOrderAlpha  ScriptOrder = "alpha"
+)
+
 // Config holds the user configuration.
 type Config struct {
 	KeyScheme     KeyScheme   `json:"key_scheme"`
@@ -38,6 +46,7 @@ type Config struct {
 	ColorScheme   ColorScheme `json:"color_scheme"`
 	CustomUpKey   byte        `json:"custom_up_key,omitempty"`
 	CustomDownKey byte        `json:"custom_down_key,omitempty"`
+	ScriptOrder   ScriptOrder `json:"script_order,omitempty"`
 }
 
 // Manager handles persistent configuration.
@@ -64,6 +73,7 @@ func New() (*Manager, error) {
 			KeyScheme:   KeySchemeArrows,
 			Language:    i18n.LangEN,
 			ColorScheme: ColorSchemeRainbow,
+			ScriptOrder: ScriptOrderRecent,
 		},
 	}
 
@@ -92,6 +102,9 @@ func (m *Manager) load() error {
 	if m.Config.ColorScheme == "" {
 		m.Config.ColorScheme = ColorSchemeRainbow
 	}
+	if m.Config.ScriptOrder == "" {
+		m.Config.ScriptOrder = ScriptOrderRecent
+	}
 	return nil
 }
 
@@ -158,6 +171,12 @@ func RunKeysSetup() (KeyScheme, error) {
 	return promptKeys()
 }
 
+// RunOrderSetup prompts the user to change the script order.
+func RunOrderSetup() (ScriptOrder, error) {
+	fmt.Printf("%s%sskit — order%s\n\n", ansi.Bold, ansi.Purple, ansi.Reset)
+	return promptOrder()
+}
+
 func promptLang() (i18n.Lang, error) {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -261,3 +280,29 @@ func promptKeys() (KeyScheme, error) {
 		}
 	}
 }
+
+func promptOrder() (ScriptOrder, error) {
+	reader := bufio.NewReader(os.Stdin)
+	m := i18n.Get()
+
+	fmt.Printf("  %s\n\n", m.ConfigOrderPrompt)
+	fmt.Printf("  %s1.%s %s %s%s%s\n", ansi.Purple, ansi.Reset, m.ConfigOrderRecent, ansi.Gray, m.ConfigOrderRecentHint, ansi.Reset)
+	fmt.Printf("  %s2.%s %s %s%s%s\n", ansi.Purple, ansi.Reset, m.ConfigOrderAlpha, ansi.Gray, m.ConfigOrderAlphaHint, ansi.Reset)
+	fmt.Printf("\n%s%s%s", ansi.Gray, m.ConfigOrderChoice, ansi.Reset)
+
+	for {
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+
+		switch input {
+		case "", "1":
+			fmt.Printf("\n%s%s%s%s\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.ConfigOrderConfirm, m.ConfigOrderRecent), ansi.Reset)
+			return ScriptOrderRecent, nil
+		case "2":
+			fmt.Printf("\n%s%s%s%s\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.ConfigOrderConfirm, m.ConfigOrderAlpha), ansi.Reset)
+			return ScriptOrderAlpha, nil
+		default:
+			fmt.Printf("%s%s%s", ansi.Gray, m.ConfigOrderInvalid, ansi.Reset)
+		}
+	}
+}
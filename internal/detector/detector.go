@@ -1,6 +1,12 @@
 package detector
 
-import "os"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
 
 // PackageManager represents a Node.js package manager.
 type PackageManager int
@@ -10,40 +16,348 @@ const (
 	Yarn
 	PNPM
 	Bun
+	YarnBerry // Yarn 2 and later
+	Deno
+
+	// Task runners, for scripts from a Makefile, justfile or Taskfile.
+	Make
+	Just
+	Task
+
+	// PHP and Python runners, for scripts from composer.json and
+	// pyproject.toml.
+	Composer
+	Poe
+	PDM
+	Hatch
 )
 
+// tools lists the task runners by the parser.Script source they run.
+var tools = map[string]Info{
+	"make": {Manager: Make, Name: "make", RunCmd: "make"},
+	"just": {Manager: Just, Name: "just", RunCmd: "just"},
+	"task": {Manager: Task, Name: "task", RunCmd: "task"},
+
+	"composer": {Manager: Composer, Name: "composer", RunCmd: "composer run-script"},
+	"poe":      {Manager: Poe, Name: "poe", RunCmd: "poe"},
+	"pdm":      {Manager: PDM, Name: "pdm", RunCmd: "pdm run"},
+	"hatch":    {Manager: Hatch, Name: "hatch", RunCmd: "hatch run"},
+}
+
+// ForTool returns the task runner for scripts of the given source, if it is
+// not a package manager's.
+func ForTool(source string) (Info, bool) {
+	info, ok := tools[source]
+	return info, ok
+}
+
 // Info holds the detected package manager and its run command.
 type Info struct {
 	Manager PackageManager
 	Name    string // "npm", "yarn", "pnpm", "bun"
 	RunCmd  string // "npm run", "yarn run", "pnpm run", "bun run"
+	Version string // version or range pinned in package.json, empty when none
+	PnP     bool   // Yarn Berry with Plug'n'Play installs, without node_modules
+
+	// Evidence lists every file pointing to a package manager, the one that
+	// decided first. It is empty when nothing was found and npm is the
+	// default.
+	Evidence []Evidence
 }
 
-// Detect examines a directory for lockfiles and returns the appropriate package manager.
-// Priority: bun > pnpm > yarn > npm.
+// Evidence is a file pointing to a package manager: a package.json field or
+// a lockfile.
+type Evidence struct {
+	Path    string // path of the file
+	Field   string // "packageManager" or "devEngines.packageManager", empty for a lockfile
+	Name    string // package manager it points to
+	Version string // version or range pinned by the field
+	Root    bool   // found at the monorepo root rather than in the package
+}
+
+// managers lists the package managers by detection priority, with their
+// lockfiles.
+var managers = []struct {
+	info      Info
+	lockfiles []string
+}{
+	{Info{Manager: Bun, Name: "bun", RunCmd: "bun run"}, []string{"bun.lockb", "bun.lock"}},
+	{Info{Manager: PNPM, Name: "pnpm", RunCmd: "pnpm run"}, []string{"pnpm-lock.yaml"}},
+	{Info{Manager: Yarn, Name: "yarn", RunCmd: "yarn run"}, []string{"yarn.lock"}},
+	{Info{Manager: NPM, Name: "npm", RunCmd: "npm run"}, []string{"package-lock.json"}},
+}
+
+// DetectDeno returns the runner of the tasks in a deno.json or deno.jsonc
+// file, with the config file and deno.lock as evidence.
+func DetectDeno(configPath string) Info {
+	info := Info{Manager: Deno, Name: "deno", RunCmd: "deno task"}
+	info.Evidence = []Evidence{{Path: configPath, Name: "deno"}}
+	if lock := filepath.Join(filepath.Dir(configPath), "deno.lock"); fileExists(lock) {
+		info.Evidence = append(info.Evidence, Evidence{Path: lock, Name: "deno"})
+	}
+	return info
+}
+
+// Detect examines a directory and returns the appropriate package manager.
+// See DetectFrom.
 func Detect(dir string) Info {
-	// Bun
-	if fileExists(dir+"/bun.lockb") || fileExists(dir+"/bun.lock") {
-		return Info{Manager: Bun, Name: "bun", RunCmd: "bun run"}
+	return DetectFrom(dir, "")
+}
+
+// DetectFrom returns the package manager of the package in pkgDir, part of
+// the monorepo in rootDir when it is not empty. A manager declared in
+// package.json wins, the packageManager field used by Corepack before
+// devEngines.packageManager, then lockfiles with the priority
+// bun > pnpm > yarn > npm; the package is looked at before the root. npm is
+// the default.
+func DetectFrom(pkgDir, rootDir string) Info {
+	dirs := []string{pkgDir}
+	if rootDir != "" && filepath.Clean(rootDir) != filepath.Clean(pkgDir) {
+		dirs = append(dirs, rootDir)
 	}
 
-	// PNPM
-	if fileExists(dir + "/pnpm-lock.yaml") {
-		return Info{Manager: PNPM, Name: "pnpm", RunCmd: "pnpm run"}
+	var declared, locks []Evidence
+	for level, dir := range dirs {
+		for _, e := range readDeclared(filepath.Join(dir, "package.json")) {
+			e.Root = level > 0
+			declared = append(declared, e)
+		}
+		for _, m := range managers {
+			for _, name := range m.lockfiles {
+				if path := filepath.Join(dir, name); fileExists(path) {
+					locks = append(locks, Evidence{Path: path, Name: m.info.Name, Root: level > 0})
+				}
+			}
+		}
 	}
 
-	// Yarn
-	if fileExists(dir + "/yarn.lock") {
-		return Info{Manager: Yarn, Name: "yarn", RunCmd: "yarn run"}
+	evidence := append(declared, locks...)
+	if len(evidence) == 0 {
+		return managers[len(managers)-1].info
+	}
+	info, _ := byName(evidence[0].Name)
+	info.Version = evidence[0].Version
+	info.Evidence = evidence
+	if info.Manager == Yarn && isYarnBerry(dirs, info.Version) {
+		info.Manager = YarnBerry
+		info.PnP = usesPnP(dirs)
 	}
+	return info
+}
+
+// isYarnBerry reports whether yarn is Yarn 2 or later: the pinned version
+// says so when there is one, then .yarnrc.yml or .yarn/releases, which Yarn
+// Classic does not use.
+func isYarnBerry(dirs []string, version string) bool {
+	if major, ok := majorVersion(version); ok {
+		return major >= 2
+	}
+	for _, dir := range dirs {
+		if fileExists(filepath.Join(dir, ".yarnrc.yml")) || fileExists(filepath.Join(dir, ".yarn", "releases")) {
+			return true
+		}
+	}
+	return false
+}
+
+// majorVersion returns the major version of a version or range such as
+// "4.1.0" or "^3".
+func majorVersion(version string) (int, bool) {
+	version = strings.TrimLeft(version, "^~>=v ")
+	end := strings.IndexFunc(version, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(version)
+	}
+	major, err := strconv.Atoi(version[:end])
+	return major, err == nil
+}
+
+// usesPnP reports whether Yarn Berry installs with Plug'n'Play: a .pnp.cjs
+// loader exists, or the nodeLinker setting of the nearest .yarnrc.yml is
+// "pnp", the default.
+func usesPnP(dirs []string) bool {
+	for _, dir := range dirs {
+		if fileExists(filepath.Join(dir, ".pnp.cjs")) || fileExists(filepath.Join(dir, ".pnp.js")) {
+			return true
+		}
+	}
+	for _, dir := range dirs {
+		if linker, ok := nodeLinker(filepath.Join(dir, ".yarnrc.yml")); ok {
+			return linker == "pnp"
+		}
+	}
+	return true
+}
 
-	// npm
-	if fileExists(dir + "/package-lock.json") {
-		return Info{Manager: NPM, Name: "npm", RunCmd: "npm run"}
+// nodeLinker reads the top-level nodeLinker setting of a .yarnrc.yml.
+func nodeLinker(path string) (string, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(line, "nodeLinker:"); ok {
+			value, _, _ = strings.Cut(value, " #")
+			return strings.Trim(strings.TrimSpace(value), `"'`), true
+		}
+	}
+	return "", false
+}
+
+// readDeclared returns the package managers declared by a package.json.
+func readDeclared(pkgPath string) []Evidence {
+	data, err := os.ReadFile(pkgPath)
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+		DevEngines     struct {
+			PackageManager json.RawMessage `json:"packageManager"`
+		} `json:"devEngines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
 	}
 
-	// Default: npm
-	return Info{Manager: NPM, Name: "npm", RunCmd: "npm run"}
+	var found []Evidence
+	if info, ok := ParsePackageManager(pkg.PackageManager); ok {
+		found = append(found, Evidence{Path: pkgPath, Field: "packageManager", Name: info.Name, Version: info.Version})
+	}
+	// devEngines.packageManager is an object, or a list of them in order of
+	// preference.
+	var engines []devEngine
+	var one devEngine
+	if err := json.Unmarshal(pkg.DevEngines.PackageManager, &one); err == nil {
+		engines = []devEngine{one}
+	} else {
+		_ = json.Unmarshal(pkg.DevEngines.PackageManager, &engines)
+	}
+	for _, e := range engines {
+		if _, ok := byName(e.Name); ok {
+			found = append(found, Evidence{Path: pkgPath, Field: "devEngines.packageManager", Name: e.Name, Version: e.Version})
+			break
+		}
+	}
+	return found
+}
+
+// devEngine is an entry of devEngines.packageManager.
+type devEngine struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ParsePackageManager parses a packageManager field such as
+// "pnpm@9.1.0+sha512.abc", dropping the hash.
+func ParsePackageManager(spec string) (Info, bool) {
+	name, version, _ := strings.Cut(strings.TrimSpace(spec), "@")
+	info, ok := byName(name)
+	if !ok {
+		return Info{}, false
+	}
+	info.Version, _, _ = strings.Cut(version, "+")
+	return info, true
+}
+
+func byName(name string) (Info, bool) {
+	for _, m := range managers {
+		if m.info.Name == name {
+			return m.info, true
+		}
+	}
+	return Info{}, false
+}
+
+// TaskRunner reports whether the runner reads its tasks from its own file,
+// as deno, the task runners and the PHP and Python runners do, rather than
+// running package.json scripts.
+func (i Info) TaskRunner() bool {
+	switch i.Manager {
+	case Deno, Make, Just, Task, Composer, Poe, PDM, Hatch:
+		return true
+	}
+	return false
+}
+
+// Declared reports whether the manager comes from a package.json field
+// rather than a lockfile or the default.
+func (i Info) Declared() bool {
+	return len(i.Evidence) > 0 && i.Evidence[0].Field != ""
+}
+
+// Conflicts returns the evidence pointing to another package manager than
+// the detected one.
+func (i Info) Conflicts() []Evidence {
+	var out []Evidence
+	for _, e := range i.Evidence {
+		if e.Name != i.Name {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Label returns the manager name with its pinned version, as in "pnpm@9.1.0".
+func (i Info) Label() string {
+	if i.Version == "" {
+		return i.Name
+	}
+	return i.Name + "@" + i.Version
+}
+
+// RunArgs returns the command line that runs script with extra arguments
+// forwarded to it. npm, pnpm, task and composer need a "--" separator so the
+// arguments reach the script instead of the runner; the others forward them
+// as-is.
+func (i Info) RunArgs(script string, extra []string) []string {
+	args := strings.Fields(i.RunCmd)
+	args = append(args, script)
+	return i.withExtra(args, extra)
+}
+
+// RunsHooks reports whether the runner runs the "pre" and "post" hooks of a
+// package.json script along with it. Yarn 2 and later only run them for
+// lifecycle events such as install.
+func (i Info) RunsHooks() bool {
+	switch i.Manager {
+	case NPM, Yarn, PNPM, Bun:
+		return true
+	}
+	return false
+}
+
+// NoHooksRunArgs returns the command line that runs script without its pre
+// and post hooks, and false when the runner cannot skip them.
+func (i Info) NoHooksRunArgs(script string, extra []string) ([]string, bool) {
+	var args []string
+	switch i.Manager {
+	case NPM:
+		args = []string{"npm", "run", "--ignore-scripts", script}
+	case PNPM:
+		args = []string{"pnpm", "run", "--config.enable-pre-post-scripts=false", script}
+	default:
+		return i.RunArgs(script, extra), !i.RunsHooks()
+	}
+	return i.withExtra(args, extra), true
+}
+
+// WorkspaceRunArgs returns the command line that runs script in the
+// workspace package named workspace from the monorepo root, with
+// "yarn workspace", as Yarn Berry needs.
+func (i Info) WorkspaceRunArgs(workspace, script string, extra []string) []string {
+	return i.withExtra([]string{"yarn", "workspace", workspace, "run", script}, extra)
+}
+
+func (i Info) withExtra(args, extra []string) []string {
+	if len(extra) == 0 {
+		return args
+	}
+	switch i.Manager {
+	case NPM, PNPM, Task, Composer:
+		args = append(args, "--")
+	}
+	return append(args, extra...)
 }
 
 func fileExists(path string) bool {
@@ -3,6 +3,7 @@ package detector
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -89,3 +90,237 @@ func TestDetectPriority(t *testing.T) {
 		t.Errorf("expected Bun (highest priority), got %d", info.Manager)
 	}
 }
+
+func TestDetectPackageManagerField(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"packageManager": "pnpm@9.1.0+sha512.abc"}`), 0644)
+
+	info := Detect(dir)
+	if info.Manager != PNPM || info.Version != "9.1.0" {
+		t.Errorf("expected PNPM 9.1.0, got %d %q", info.Manager, info.Version)
+	}
+	if info.Label() != "pnpm@9.1.0" {
+		t.Errorf("expected label 'pnpm@9.1.0', got %q", info.Label())
+	}
+	if c := info.Conflicts(); len(c) != 0 {
+		t.Errorf("expected no conflict, got %v", c)
+	}
+}
+
+func TestDetectPackageManagerOverLockfile(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"packageManager": "yarn@1.22.22"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte{}, 0644)
+
+	info := Detect(dir)
+	if info.Manager != Yarn {
+		t.Errorf("expected Yarn, got %d", info.Manager)
+	}
+	if c := info.Conflicts(); len(c) != 1 || filepath.Base(c[0].Path) != "package-lock.json" {
+		t.Errorf("expected a conflict with package-lock.json, got %v", c)
+	}
+}
+
+func TestDetectDevEngines(t *testing.T) {
+	tests := []struct {
+		pkg     string
+		want    PackageManager
+		version string
+	}{
+		{`{"devEngines": {"packageManager": {"name": "bun", "version": "^1.1.0"}}}`, Bun, "^1.1.0"},
+		{`{"devEngines": {"packageManager": [{"name": "deno"}, {"name": "pnpm"}]}}`, PNPM, ""},
+		{`{"packageManager": "yarn@1.22.22", "devEngines": {"packageManager": {"name": "pnpm"}}}`, Yarn, "1.22.22"},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, "package.json"), []byte(tt.pkg), 0644)
+
+		info := Detect(dir)
+		if info.Manager != tt.want || info.Version != tt.version || !info.Declared() {
+			t.Errorf("Detect(%s) = %d %q declared=%v, want %d %q", tt.pkg, info.Manager, info.Version, info.Declared(), tt.want, tt.version)
+		}
+	}
+}
+
+func TestDetectIgnoresUnknownPackageManager(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"packageManager": "deno@2.0.0"}`), 0644)
+	os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte{}, 0644)
+
+	info := Detect(dir)
+	if info.Manager != Yarn || info.Declared() {
+		t.Errorf("expected Yarn from yarn.lock, got %d declared=%v", info.Manager, info.Declared())
+	}
+}
+
+func TestDetectLockfileConflict(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "yarn.lock"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(dir, "package-lock.json"), []byte{}, 0644)
+
+	info := Detect(dir)
+	if info.Manager != Yarn {
+		t.Errorf("expected Yarn, got %d", info.Manager)
+	}
+	if len(info.Evidence) != 2 || filepath.Base(info.Evidence[0].Path) != "yarn.lock" {
+		t.Errorf("expected yarn.lock first in the evidence, got %v", info.Evidence)
+	}
+	if c := info.Conflicts(); len(c) != 1 || c[0].Name != "npm" {
+		t.Errorf("expected a conflict with npm, got %v", c)
+	}
+}
+
+func TestDetectFromRoot(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "packages", "ui")
+	os.MkdirAll(pkg, 0755)
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{"packageManager": "pnpm@9.1.0"}`), 0644)
+	os.WriteFile(filepath.Join(root, "pnpm-lock.yaml"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(pkg, "package.json"), []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(pkg, "package-lock.json"), []byte{}, 0644)
+
+	// The root declaration wins over the package lockfile.
+	info := DetectFrom(pkg, root)
+	if info.Manager != PNPM || !info.Declared() || !info.Evidence[0].Root {
+		t.Errorf("expected PNPM declared at the root, got %d %v", info.Manager, info.Evidence)
+	}
+	if c := info.Conflicts(); len(c) != 1 || c[0].Root || c[0].Name != "npm" {
+		t.Errorf("expected a conflict with the package lockfile, got %v", c)
+	}
+
+	// Without it, the package lockfile wins over the root one.
+	os.WriteFile(filepath.Join(root, "package.json"), []byte(`{}`), 0644)
+	if info := DetectFrom(pkg, root); info.Manager != NPM || info.Evidence[0].Root {
+		t.Errorf("expected NPM from the package lockfile, got %d %v", info.Manager, info.Evidence)
+	}
+}
+
+func TestDetectYarnBerry(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  PackageManager
+		pnp   bool
+	}{
+		{"classic lockfile", map[string]string{"yarn.lock": ""}, Yarn, false},
+		{"yarnrc", map[string]string{"yarn.lock": "", ".yarnrc.yml": "yarnPath: .yarn/releases/yarn-4.1.0.cjs\n"}, YarnBerry, true},
+		{"node-modules linker", map[string]string{"yarn.lock": "", ".yarnrc.yml": "nodeLinker: node-modules\n"}, YarnBerry, false},
+		{"releases", map[string]string{"yarn.lock": "", ".yarn/releases/yarn-3.6.4.cjs": ""}, YarnBerry, true},
+		{"pnp loader", map[string]string{".yarnrc.yml": "nodeLinker: 'node-modules'\n", ".pnp.cjs": "", "package.json": `{"packageManager": "yarn@4.1.0"}`}, YarnBerry, true},
+		{"packageManager", map[string]string{"package.json": `{"packageManager": "yarn@3.6.4"}`}, YarnBerry, true},
+		{"classic pinned", map[string]string{"package.json": `{"packageManager": "yarn@1.22.22"}`, ".yarnrc.yml": ""}, Yarn, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				os.MkdirAll(filepath.Dir(path), 0755)
+				os.WriteFile(path, []byte(content), 0644)
+			}
+
+			info := Detect(dir)
+			if info.Manager != tt.want || info.PnP != tt.pnp {
+				t.Errorf("got manager %d pnp=%v, want %d pnp=%v", info.Manager, info.PnP, tt.want, tt.pnp)
+			}
+			if info.Name != "yarn" || info.RunCmd != "yarn run" {
+				t.Errorf("got %q %q, want yarn and 'yarn run'", info.Name, info.RunCmd)
+			}
+			// Only Yarn 1 runs the pre and post hooks of "yarn run".
+			if got := info.RunsHooks(); got != (tt.want == Yarn) {
+				t.Errorf("RunsHooks = %v, want %v", got, tt.want == Yarn)
+			}
+		})
+	}
+}
+
+func TestDetectYarnBerryAtRoot(t *testing.T) {
+	root := t.TempDir()
+	pkg := filepath.Join(root, "packages", "ui")
+	os.MkdirAll(pkg, 0755)
+	os.WriteFile(filepath.Join(root, "yarn.lock"), []byte{}, 0644)
+	os.WriteFile(filepath.Join(root, ".yarnrc.yml"), []byte("nodeLinker: pnp\n"), 0644)
+
+	info := DetectFrom(pkg, root)
+	if info.Manager != YarnBerry || !info.PnP {
+		t.Errorf("expected Yarn Berry with PnP, got %d pnp=%v", info.Manager, info.PnP)
+	}
+}
+
+func TestDetectDeno(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "deno.json")
+	os.WriteFile(config, []byte(`{}`), 0644)
+	os.WriteFile(filepath.Join(dir, "deno.lock"), []byte(`{}`), 0644)
+
+	info := DetectDeno(config)
+	if info.Manager != Deno || info.RunCmd != "deno task" {
+		t.Errorf("expected Deno with 'deno task', got %d %q", info.Manager, info.RunCmd)
+	}
+	if len(info.Evidence) != 2 || len(info.Conflicts()) != 0 {
+		t.Errorf("expected deno.json and deno.lock as evidence, got %v", info.Evidence)
+	}
+	if got := strings.Join(info.RunArgs("dev", []string{"--port", "8000"}), " "); got != "deno task dev --port 8000" {
+		t.Errorf("RunArgs = %q, want 'deno task dev --port 8000'", got)
+	}
+}
+
+func TestWorkspaceRunArgs(t *testing.T) {
+	info := Info{Manager: YarnBerry}
+	got := strings.Join(info.WorkspaceRunArgs("@acme/ui", "test", []string{"--watch"}), " ")
+	if want := "yarn workspace @acme/ui run test --watch"; got != want {
+		t.Errorf("WorkspaceRunArgs = %q, want %q", got, want)
+	}
+}
+
+func TestRunArgs(t *testing.T) {
+	tests := []struct {
+		info  Info
+		extra []string
+		want  string
+	}{
+		{Info{Manager: NPM, RunCmd: "npm run"}, nil, "npm run test"},
+		{Info{Manager: NPM, RunCmd: "npm run"}, []string{"--watch", "src/foo"}, "npm run test -- --watch src/foo"},
+		{Info{Manager: PNPM, RunCmd: "pnpm run"}, []string{"--watch"}, "pnpm run test -- --watch"},
+		{Info{Manager: Yarn, RunCmd: "yarn run"}, []string{"--watch"}, "yarn run test --watch"},
+		{Info{Manager: Bun, RunCmd: "bun run"}, []string{"--watch"}, "bun run test --watch"},
+		{Info{Manager: YarnBerry, RunCmd: "yarn run"}, []string{"--watch"}, "yarn run test --watch"},
+		{tools["make"], []string{"V=1"}, "make test V=1"},
+		{tools["just"], []string{"--release"}, "just test --release"},
+		{tools["task"], []string{"--watch"}, "task test -- --watch"},
+		{tools["composer"], []string{"--filter", "Foo"}, "composer run-script test -- --filter Foo"},
+		{tools["poe"], []string{"-k", "slow"}, "poe test -k slow"},
+		{tools["pdm"], []string{"-x"}, "pdm run test -x"},
+		{tools["hatch"], []string{"-x"}, "hatch run test -x"},
+	}
+
+	for _, tt := range tests {
+		got := strings.Join(tt.info.RunArgs("test", tt.extra), " ")
+		if got != tt.want {
+			t.Errorf("RunArgs(%v) = %q, want %q", tt.extra, got, tt.want)
+		}
+	}
+}
+
+func TestNoHooksRunArgs(t *testing.T) {
+	tests := []struct {
+		info Info
+		want string
+		ok   bool
+	}{
+		{Info{Manager: NPM, RunCmd: "npm run"}, "npm run --ignore-scripts build -- --watch", true},
+		{Info{Manager: PNPM, RunCmd: "pnpm run"}, "pnpm run --config.enable-pre-post-scripts=false build -- --watch", true},
+		{Info{Manager: Yarn, RunCmd: "yarn run"}, "yarn run build --watch", false},
+		{Info{Manager: Bun, RunCmd: "bun run"}, "bun run build --watch", false},
+		{Info{Manager: YarnBerry, RunCmd: "yarn run"}, "yarn run build --watch", true},
+	}
+
+	for _, tt := range tests {
+		args, ok := tt.info.NoHooksRunArgs("build", []string{"--watch"})
+		if got := strings.Join(args, " "); got != tt.want || ok != tt.ok {
+			t.Errorf("NoHooksRunArgs(%v) = %q, %v, want %q, %v", tt.info.Manager, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChangedFiles returns the files of the repository containing dir that
+// differ from the merge base of ref and HEAD: committed since, staged, modified
+// or untracked. Paths are absolute.
+func ChangedFiles(dir, ref string) ([]string, error) {
+	// A ref starting with "-" would be read as an option.
+	if strings.HasPrefix(ref, "-") {
+		return nil, fmt.Errorf("invalid git ref %q", ref)
+	}
+	top, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	top = strings.TrimSpace(top)
+
+	base, err := run(dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	diff, err := run(top, "diff", "--name-only", "-z", strings.TrimSpace(base))
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := run(top, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, name := range strings.Split(diff+untracked, "\x00") {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		files = append(files, filepath.Join(top, filepath.FromSlash(name)))
+	}
+	return files, nil
+}
+
+// DefaultBranch returns the branch origin/HEAD points to in the repository
+// containing dir, such as "origin/master", or "main" when it is not set.
+func DefaultBranch(dir string) string {
+	out, err := run(dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if ref := strings.TrimSpace(out); err == nil && ref != "" {
+		return ref
+	}
+	return "main"
+}
+
+// run executes git in dir and returns its standard output.
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
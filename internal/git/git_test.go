@@ -0,0 +1,106 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testRepo creates a repository with a main branch holding a and b/c, and a
+// feature branch checked out.
+func testRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=skit", "-c", "user.email=skit@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write(t, dir, "a", "a")
+	write(t, dir, "b/c", "c")
+	gitCmd("init", "-q")
+	gitCmd("checkout", "-q", "-b", "main")
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "init")
+	gitCmd("checkout", "-q", "-b", "feature")
+	write(t, dir, "b/c", "changed")
+	gitCmd("commit", "-q", "-am", "change c")
+	return dir
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	dir := testRepo(t)
+	write(t, dir, "a", "modified")
+	write(t, dir, "new/file", "untracked")
+
+	files, err := ChangedFiles(filepath.Join(dir, "b"), "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	top, _ := filepath.EvalSymlinks(dir)
+	var rel []string
+	for _, f := range files {
+		f, _ = filepath.EvalSymlinks(f)
+		r, _ := filepath.Rel(top, f)
+		rel = append(rel, filepath.ToSlash(r))
+	}
+	slices.Sort(rel)
+	want := []string{"a", "b/c", "new/file"}
+	if !slices.Equal(rel, want) {
+		t.Errorf("ChangedFiles = %v, want %v", rel, want)
+	}
+}
+
+func TestChangedFilesUnknownRef(t *testing.T) {
+	dir := testRepo(t)
+	if _, err := ChangedFiles(dir, "no-such-branch"); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+}
+
+func TestChangedFilesRejectsOptions(t *testing.T) {
+	dir := testRepo(t)
+	if _, err := ChangedFiles(dir, "--output=/tmp/x"); err == nil {
+		t.Error("ChangedFiles accepted a ref starting with \"-\"")
+	}
+}
+
+func TestDefaultBranch(t *testing.T) {
+	dir := testRepo(t)
+	if got := DefaultBranch(dir); got != "main" {
+		t.Errorf("DefaultBranch without origin = %q, want main", got)
+	}
+
+	for _, args := range [][]string{
+		{"update-ref", "refs/remotes/origin/trunk", "HEAD"},
+		{"symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/trunk"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	if got := DefaultBranch(dir); got != "origin/trunk" {
+		t.Errorf("DefaultBranch = %q, want origin/trunk", got)
+	}
+}
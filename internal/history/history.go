@@ -4,16 +4,34 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
+// MaxEntries is the number of entries the history keeps.
+const MaxEntries = 50
+
 // Entry represents a single script execution record.
 type Entry struct {
-	Script    string    `json:"script"`
-	Command   string    `json:"command"`
-	Runner    string    `json:"runner"`
-	Directory string    `json:"directory"`
-	Timestamp time.Time `json:"timestamp"`
+	Script      string        `json:"script"`
+	Command     string        `json:"command"`
+	Runner      string        `json:"runner"`
+	Directory   string        `json:"directory"`
+	Timestamp   time.Time     `json:"timestamp"`
+	Args        []string      `json:"args,omitempty"`         // arguments forwarded to the script
+	RunCommand  string        `json:"run_command,omitempty"`  // resolved command line, e.g. "pnpm run test -- --watch"
+	PackageJSON string        `json:"package_json,omitempty"` // absolute path to the package.json used
+	Workspace   string        `json:"workspace,omitempty"`    // workspace package name, empty outside monorepos
+	ExitCode    int           `json:"exit_code"`
+	Duration    time.Duration `json:"duration,omitzero"`
+}
+
+// Completed reports whether the entry was recorded after the script finished,
+// i.e. whether ExitCode and Duration are meaningful. Entries written by older
+// versions of skit only record the start of a run.
+func (e Entry) Completed() bool {
+	return e.Duration > 0
 }
 
 // Manager handles persistent command history.
@@ -24,13 +42,8 @@ type Manager struct {
 
 // New creates a new history Manager.
 func New() (*Manager, error) {
-	dir, err := os.UserConfigDir()
+	configDir, err := stateDir()
 	if err != nil {
-		dir = os.TempDir()
-	}
-
-	configDir := filepath.Join(dir, "skit")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return nil, err
 	}
 
@@ -43,33 +56,135 @@ func New() (*Manager, error) {
 	return m, nil
 }
 
+// stateDir returns skit's configuration directory, creating it if needed.
+func stateDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	configDir := filepath.Join(dir, "skit")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+	return configDir, nil
+}
+
 // Add records a script execution in the history.
 func (m *Manager) Add(script, command, runner string) error {
-	dir, _ := os.Getwd()
-	m.entries = append([]Entry{{
-		Script:    script,
-		Command:   command,
-		Runner:    runner,
-		Directory: dir,
-		Timestamp: time.Now(),
-	}}, m.entries...)
+	return m.Record(Entry{
+		Script:  script,
+		Command: command,
+		Runner:  runner,
+	})
+}
+
+// Record stores a finished execution in the history. Directory and Timestamp
+// default to the working directory and the current time when unset.
+func (m *Manager) Record(e Entry) error {
+	if e.Directory == "" {
+		e.Directory, _ = os.Getwd()
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	m.entries = append([]Entry{e}, m.entries...)
 
-	// Keep only the 50 most recent entries
-	if len(m.entries) > 50 {
-		m.entries = m.entries[:50]
+	// Keep only the most recent entries
+	if len(m.entries) > MaxEntries {
+		m.entries = m.entries[:MaxEntries]
 	}
 
 	return m.save()
 }
 
-// Recent returns the n most recent history entries.
-func (m *Manager) Recent(n int) []Entry {
-	if n > len(m.entries) {
-		n = len(m.entries)
+// Remove deletes the entry recorded at the same time for the same script.
+func (m *Manager) Remove(e Entry) error {
+	for i, cur := range m.entries {
+		if cur.Script == e.Script && cur.Timestamp.Equal(e.Timestamp) {
+			m.entries = append(m.entries[:i], m.entries[i+1:]...)
+			return m.save()
+		}
 	}
+	return nil
+}
+
+// Recent returns the n most recent history entries, none when n is not
+// positive.
+func (m *Manager) Recent(n int) []Entry {
+	n = min(max(n, 0), len(m.entries))
 	return m.entries[:n]
 }
 
+// LastInProject returns the most recent entry that ran inside root, judged by
+// the directory skit was started from or the package.json that was used.
+func (m *Manager) LastInProject(root string) (Entry, bool) {
+	for _, e := range m.entries {
+		if isWithin(e.Directory, root) || isWithin(e.PackageJSON, root) {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+// Ranked returns the names of the scripts run from pkgPath, best first. Each
+// run scores by how recent it is and runs of the same script add up, so both
+// frequently and recently used scripts rise to the top.
+func (m *Manager) Ranked(pkgPath string, now time.Time) []string {
+	scores := make(map[string]float64)
+	var names []string
+	for _, e := range m.entries {
+		if e.PackageJSON != pkgPath {
+			continue
+		}
+		if _, ok := scores[e.Script]; !ok {
+			names = append(names, e.Script)
+		}
+		scores[e.Script] += recencyWeight(now.Sub(e.Timestamp))
+	}
+
+	// names is in most-recent-first order, which breaks ties
+	sort.SliceStable(names, func(i, j int) bool {
+		return scores[names[i]] > scores[names[j]]
+	})
+	return names
+}
+
+// LastScript returns the name of the script most recently run from pkgPath.
+func (m *Manager) LastScript(pkgPath string) string {
+	for _, e := range m.entries {
+		if e.PackageJSON == pkgPath {
+			return e.Script
+		}
+	}
+	return ""
+}
+
+func recencyWeight(age time.Duration) float64 {
+	switch {
+	case age < time.Hour:
+		return 4
+	case age < 24*time.Hour:
+		return 2
+	case age < 7*24*time.Hour:
+		return 1
+	default:
+		return 0.5
+	}
+}
+
+// isWithin reports whether path is dir or one of its descendants.
+func isWithin(path, dir string) bool {
+	if path == "" || dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
 func (m *Manager) load() error {
 	data, err := os.ReadFile(m.filePath)
 	if err != nil {
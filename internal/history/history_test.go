@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestAddAndRecent(t *testing.T) {
@@ -81,6 +82,20 @@ func TestRecentLargerThanEntries(t *testing.T) {
 	}
 }
 
+func TestRecentNotPositive(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{
+		filePath: filepath.Join(dir, "history.json"),
+	}
+
+	_ = m.Add("test", "cmd", "npm")
+	for _, n := range []int{0, -1} {
+		if entries := m.Recent(n); len(entries) != 0 {
+			t.Errorf("Recent(%d) returned %d entries, want none", n, len(entries))
+		}
+	}
+}
+
 func TestLoadNonExistent(t *testing.T) {
 	m := &Manager{
 		filePath: filepath.Join(os.TempDir(), "nonexistent-skit-test.json"),
@@ -90,3 +105,148 @@ func TestLoadNonExistent(t *testing.T) {
 		t.Error("expected error loading non-existent file")
 	}
 }
+
+func TestRecordResult(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.json")
+
+	m1 := &Manager{filePath: path}
+	err := m1.Record(Entry{
+		Script:      "lint",
+		Command:     "eslint .",
+		Runner:      "pnpm",
+		Directory:   "/work/acme",
+		Args:        []string{"--fix"},
+		RunCommand:  "pnpm run lint -- --fix",
+		PackageJSON: "/work/acme/apps/web/package.json",
+		Workspace:   "@acme/web",
+		ExitCode:    2,
+		Duration:    1500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m2 := &Manager{filePath: path}
+	_ = m2.load()
+
+	entries := m2.Recent(1)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry after reload, got %d", len(entries))
+	}
+	e := entries[0]
+	if !e.Completed() {
+		t.Error("recorded entry should be completed")
+	}
+	if e.ExitCode != 2 || e.Duration != 1500*time.Millisecond {
+		t.Errorf("result = (%d, %v), want (2, 1.5s)", e.ExitCode, e.Duration)
+	}
+	if e.Workspace != "@acme/web" || e.RunCommand != "pnpm run lint -- --fix" {
+		t.Errorf("workspace/run command not persisted: %+v", e)
+	}
+	if len(e.Args) != 1 || e.Args[0] != "--fix" {
+		t.Errorf("Args = %v, want [--fix]", e.Args)
+	}
+	if e.Timestamp.IsZero() {
+		t.Error("Timestamp should default to now")
+	}
+}
+
+func TestLegacyEntryNotCompleted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.json")
+	legacy := `[{"script":"test","command":"vitest","runner":"npm","directory":"/tmp","timestamp":"2024-01-02T15:04:05Z"}]`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &Manager{filePath: path}
+	if err := m.load(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Recent(1)[0].Completed() {
+		t.Error("legacy entry without duration should not be completed")
+	}
+}
+
+func TestLastInProject(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{filePath: filepath.Join(dir, "history.json")}
+
+	_ = m.Record(Entry{Script: "build", Directory: "/work/acme/apps/web"})
+	_ = m.Record(Entry{Script: "dev", Directory: "/work/other"})
+	_ = m.Record(Entry{Script: "lint", Directory: "/home/me", PackageJSON: "/work/acme/packages/ui/package.json"})
+	_ = m.Record(Entry{Script: "test", Directory: "/work/acme-legacy"})
+
+	e, ok := m.LastInProject("/work/acme")
+	if !ok {
+		t.Fatal("expected an entry for /work/acme")
+	}
+	if e.Script != "lint" {
+		t.Errorf("LastInProject = %q, want 'lint' (matched by package.json path)", e.Script)
+	}
+
+	e, ok = m.LastInProject("/work/other")
+	if !ok || e.Script != "dev" {
+		t.Errorf("LastInProject(/work/other) = %q, %v, want 'dev'", e.Script, ok)
+	}
+
+	if _, ok := m.LastInProject("/elsewhere"); ok {
+		t.Error("expected no entry for an unrelated directory")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "history.json")
+	m := &Manager{filePath: path}
+
+	_ = m.Add("test", "vitest", "npm")
+	_ = m.Add("build", "vite build", "npm")
+	target := m.Recent(1)[0]
+
+	if err := m.Remove(target); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := &Manager{filePath: path}
+	_ = reloaded.load()
+	entries := reloaded.Recent(10)
+	if len(entries) != 1 || entries[0].Script != "test" {
+		t.Errorf("after Remove, entries = %+v, want only 'test'", entries)
+	}
+}
+
+func TestRanked(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{filePath: filepath.Join(dir, "history.json")}
+	now := time.Now()
+	pkg := "/work/acme/package.json"
+
+	// Oldest first, Record prepends
+	_ = m.Record(Entry{Script: "build", PackageJSON: pkg, Timestamp: now.Add(-48 * time.Hour)})
+	_ = m.Record(Entry{Script: "test", PackageJSON: pkg, Timestamp: now.Add(-4 * time.Hour)})
+	_ = m.Record(Entry{Script: "test", PackageJSON: pkg, Timestamp: now.Add(-3 * time.Hour)})
+	_ = m.Record(Entry{Script: "test", PackageJSON: pkg, Timestamp: now.Add(-2 * time.Hour)})
+	_ = m.Record(Entry{Script: "other", PackageJSON: "/elsewhere/package.json", Timestamp: now})
+	_ = m.Record(Entry{Script: "lint", PackageJSON: pkg, Timestamp: now.Add(-time.Minute)})
+
+	got := m.Ranked(pkg, now)
+	want := []string{"test", "lint", "build"}
+	if len(got) != len(want) {
+		t.Fatalf("Ranked = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Ranked = %v, want %v", got, want)
+			break
+		}
+	}
+
+	if last := m.LastScript(pkg); last != "lint" {
+		t.Errorf("LastScript = %q, want 'lint'", last)
+	}
+	if last := m.LastScript("/nowhere/package.json"); last != "" {
+		t.Errorf("LastScript for unknown package = %q, want empty", last)
+	}
+}
@@ -0,0 +1,61 @@
+package history
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Workspaces remembers the workspace package last picked in each monorepo.
+type Workspaces struct {
+	filePath string
+	last     map[string]string // root package.json → picked package.json
+}
+
+// NewWorkspaces loads the remembered workspace picks.
+func NewWorkspaces() (*Workspaces, error) {
+	configDir, err := stateDir()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspaces{
+		filePath: filepath.Join(configDir, "workspaces.json"),
+	}
+
+	_ = w.load()
+
+	return w, nil
+}
+
+// Last returns the package.json last picked in the monorepo whose root
+// package.json is rootPkg, or "" if none was.
+func (w *Workspaces) Last(rootPkg string) string {
+	return w.last[rootPkg]
+}
+
+// SetLast remembers pkgPath as the package.json picked in the monorepo whose
+// root package.json is rootPkg.
+func (w *Workspaces) SetLast(rootPkg, pkgPath string) error {
+	if w.last == nil {
+		w.last = make(map[string]string)
+	}
+	w.last[rootPkg] = pkgPath
+	return w.save()
+}
+
+func (w *Workspaces) load() error {
+	data, err := os.ReadFile(w.filePath)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &w.last)
+}
+
+func (w *Workspaces) save() error {
+	data, err := json.MarshalIndent(w.last, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(w.filePath, data, 0600)
+}
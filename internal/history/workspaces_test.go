@@ -0,0 +1,32 @@
+package history
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspacesLast(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "workspaces.json")
+	w := &Workspaces{filePath: path}
+
+	if got := w.Last("/repo/package.json"); got != "" {
+		t.Errorf("Last on empty state = %q, want empty", got)
+	}
+	if err := w.SetLast("/repo/package.json", "/repo/apps/web/package.json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetLast("/other/package.json", "/other/packages/a/package.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded := &Workspaces{filePath: path}
+	if err := reloaded.load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.Last("/repo/package.json"); got != "/repo/apps/web/package.json" {
+		t.Errorf("Last(/repo) = %q, want /repo/apps/web/package.json", got)
+	}
+	if got := reloaded.Last("/other/package.json"); got != "/other/packages/a/package.json" {
+		t.Errorf("Last(/other) = %q, want /other/packages/a/package.json", got)
+	}
+}
@@ -22,13 +22,27 @@ type Messages struct {
 	ErrNoScripts       string
 	Cancelled          string
 	Executing          string
+	ExecutingParallel  string
 	ErrCommandFailed   string
+	ErrScriptExited    string
 	Success            string
+	ParallelSummary    string
+	ParallelKilled     string
+	ParallelFailed     string
+	ParallelSkipped    string
+	ParallelNotRun     string
 	ErrGeneric         string
 	ErrSaveConfig      string
 	ErrReadHistory     string
 	HistoryEmpty       string
 	HistoryTitle       string
+	HistoryHint        string
+	HistoryRemove      string // (script)
+	HistoryCount       string
+	HistoryPrompt      string
+	NoMatchingEntries  string
+	ErrNoHistoryEntry  string
+	ErrNothingToReplay string
 	TimeJustNow        string
 	TimeMinutesAgo     string
 	TimeHoursAgo       string
@@ -37,9 +51,36 @@ type Messages struct {
 	VersionFormat      string
 	DetectedRunner     string
 	ContextLine        string // "%s  ▸  %s" (path, runner)
+	RunnerConflict     string // (files pointing to other managers)
+	HooksNotSkipped    string // (runner, script)
+	ConfirmRun         string // (script)
+	ConfirmYes         string // accepted answers, space-separated
+	ErrCwdOutside      string // (cwd, script)
+	WhichRunner        string
+	WhichPackage       string
+	WhichBerry         string
+	WhichBerryPnP      string
+	WhichDeclared      string
+	WhichLockfile      string
+	WhichSource        string
+	WhichDefault       string
+	WhichPriority      string
+	WhichEvidence      string
+	WhichConflict      string
+	WhichRoot          string
 	WorkspaceDetected  string
 	WorkspacePrompt    string
-	WorkspaceInvalid   string
+	WorkspaceScript    string
+	WorkspaceScripts   string
+	WorkspaceCountFmt  string
+	NoMatchingPackages string
+	ErrNoWorkspaces    string
+	ErrNoWorkspaceRun  string
+	ErrNoFilterMatch   string
+	ChangedPackages    string
+	NoChangedPackages  string
+	ExecutingAll       string
+	WorkspaceCount     string
 	UsingRoot          string
 
 	// ui/menu.go
@@ -48,6 +89,10 @@ type Messages struct {
 	FilterActiveLabel string
 	NoMatchingScripts string
 	ScriptCount       string
+	MenuRecent        string
+	MenuAllScripts    string
+	MenuDependsOn     string // "after %s" (tasks run first)
+	MenuMultiHint     string
 	HelpArrows        string
 	HelpWASD          string
 	HelpCustomFmt     string
@@ -86,6 +131,16 @@ type Messages struct {
 	ConfigColorChoice           string
 	ConfigColorConfirm          string
 	ConfigColorInvalid          string
+
+	// config.go — script order
+	ConfigOrderPrompt     string
+	ConfigOrderRecent     string
+	ConfigOrderRecentHint string
+	ConfigOrderAlpha      string
+	ConfigOrderAlphaHint  string
+	ConfigOrderChoice     string
+	ConfigOrderConfirm    string
+	ConfigOrderInvalid    string
 }
 
 var (
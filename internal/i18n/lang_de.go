@@ -3,19 +3,33 @@ package i18n
 var messagesDE = Messages{
 	// main.go
 	ErrConfig:          "Konfigurationsfehler: %v",
-	ErrNoPackageJSON:   "Fehler: kein package.json gefunden.",
+	ErrNoPackageJSON:   "Fehler: keine Projektdatei gefunden (package.json, deno.json, composer.json, pyproject.toml, Makefile, justfile oder Taskfile).",
 	PackageJSONFound:   "package.json gefunden: %s",
-	ErrReadPackageJSON: "Fehler: package.json konnte nicht gelesen werden: %v",
+	ErrReadPackageJSON: "Fehler: %s konnte nicht gelesen werden: %v",
 	ErrNoScripts:       "Fehler: keine Scripts in package.json gefunden.",
 	Cancelled:          "Abgebrochen.",
 	Executing:          "Ausführung: %s %s",
+	ExecutingParallel:  "Parallele Ausführung: %s",
 	ErrCommandFailed:   "Fehler: Befehl fehlgeschlagen: %v",
+	ErrScriptExited:    "Fehler: '%s' wurde mit Code %d beendet.",
 	Success:            "Erfolgreich abgeschlossen.",
+	ParallelSummary:    "Zusammenfassung:",
+	ParallelKilled:     "beendet",
+	ParallelFailed:     "%d von %d fehlgeschlagen.",
+	ParallelSkipped:    "übersprungen",
+	ParallelNotRun:     "%d von %d nicht ausgeführt.",
 	ErrGeneric:         "Fehler: %v",
 	ErrSaveConfig:      "Fehler: Konfiguration konnte nicht gespeichert werden: %v",
 	ErrReadHistory:     "Fehler: Verlauf konnte nicht gelesen werden: %v",
 	HistoryEmpty:       "Keine Befehle im Verlauf.",
 	HistoryTitle:       "Script-Verlauf",
+	HistoryHint:        "entf löschen",
+	HistoryRemove:      "%s aus dem Verlauf löschen? [j/N]",
+	HistoryCount:       "(%d/%d Einträge)",
+	HistoryPrompt:      "Nummer des erneut auszuführenden Eintrags (oder q zum Beenden): ",
+	NoMatchingEntries:  "(keine passenden Einträge)",
+	ErrNoHistoryEntry:  "Fehler: kein Verlaufseintrag #%s.",
+	ErrNothingToReplay: "Fehler: in diesem Projekt gibt es noch nichts zu wiederholen.",
 	TimeJustNow:        "gerade eben",
 	TimeMinutesAgo:     "vor %dMin",
 	TimeHoursAgo:       "vor %dStd",
@@ -24,9 +38,36 @@ var messagesDE = Messages{
 	VersionFormat:      "skit version %s",
 	DetectedRunner:     "Erkannt: %s",
 	ContextLine:        "%s  ▸  %s",
+	RunnerConflict:     "Warnung: widersprüchliche Hinweise auf den Paketmanager: %s. Details mit skit --which.",
+	HooksNotSkipped:    "Warnung: %s kann die pre/post-Hooks von %s nicht überspringen; sie laufen mit.",
+	ConfirmRun:         "%s ausführen? [j/N] ",
+	ConfirmYes:         "j ja y yes",
+	ErrCwdOutside:      "das x-skit-cwd %q von %s ist kein Verzeichnis im Paket",
+	WhichRunner:        "Runner: %s (%s)",
+	WhichPackage:       "Paket: %s",
+	WhichBerry:         "Yarn Berry (2+), mit node_modules.",
+	WhichBerryPnP:      "Yarn Berry (2+), mit Plug'n'Play: ohne node_modules.",
+	WhichDeclared:      "Gewählt, weil %s ihn deklariert.",
+	WhichLockfile:      "Gewählt wegen %s; keine package.json deklariert einen Paketmanager.",
+	WhichSource:        "Gewählt, weil die Tasks aus %s stammen.",
+	WhichDefault:       "Kein packageManager-Feld und keine Lockfile gefunden: npm ist der Standard.",
+	WhichPriority:      "Reihenfolge: packageManager, devEngines.packageManager, dann Lockfiles (bun > pnpm > yarn > npm); das Paket vor dem Monorepo-Root.",
+	WhichEvidence:      "Gefunden:",
+	WhichConflict:      "Konflikt",
+	WhichRoot:          "Monorepo-Root",
 	WorkspaceDetected:  "Workspaces erkannt (%d Pakete)",
 	WorkspacePrompt:    "Workspace-Nummer (oder q zum Beenden): ",
-	WorkspaceInvalid:   "Ungültige Auswahl. Nummer zwischen 1 und %d (oder q): ",
+	WorkspaceScript:    "%d Skript",
+	WorkspaceScripts:   "%d Skripte",
+	WorkspaceCountFmt:  "(%d/%d Pakete)",
+	NoMatchingPackages: "Keine passenden Pakete.",
+	ErrNoWorkspaces:    "Fehler: keine Workspaces in diesem Projekt gefunden.",
+	ErrNoWorkspaceRun:  "Fehler: kein Workspace definiert ein Skript '%s'.",
+	ErrNoFilterMatch:   "Fehler: kein Workspace-Paket passt zu --filter %s.",
+	ChangedPackages:    "Geändert seit %s: %s",
+	NoChangedPackages:  "Kein Workspace-Paket seit %s geändert.",
+	ExecutingAll:       "Führe %s in %d Paketen aus",
+	WorkspaceCount:     "in %d Paketen",
 	UsingRoot:          "Verwende Root-package.json",
 
 	// ui/menu.go
@@ -35,6 +76,10 @@ var messagesDE = Messages{
 	FilterActiveLabel: "Aktiver Filter: ",
 	NoMatchingScripts: "(keine passenden Scripts)",
 	ScriptCount:       "(%d/%d Scripts)",
+	MenuRecent:        "Zuletzt verwendet",
+	MenuAllScripts:    "Alle Skripte",
+	MenuDependsOn:     "nach %s",
+	MenuMultiHint:     "Leertaste Mehrfachauswahl",
 	HelpArrows:        "↑/↓ navigieren  •  / filtern  •  Enter auswählen  •  q beenden",
 	HelpWASD:          "↑/↓/w/s navigieren  •  / filtern  •  Enter auswählen  •  q beenden",
 	HelpCustomFmt:     "↑/↓/%s/%s navigieren  •  / filtern  •  Enter auswählen  •  %s",
@@ -73,4 +118,14 @@ var messagesDE = Messages{
 	ConfigColorChoice:           "Auswahl [1/2/3/4] (Standard: 1): ",
 	ConfigColorConfirm:          "Farben: %s",
 	ConfigColorInvalid:          "Ungültige Auswahl. Gib 1, 2, 3 oder 4 ein: ",
+
+	// config.go — script order
+	ConfigOrderPrompt:     "Wie sollen Skripte im Menü sortiert werden?",
+	ConfigOrderRecent:     "Zuletzt verwendete zuerst",
+	ConfigOrderRecentHint: "(häufig genutzte Skripte oben anheften)",
+	ConfigOrderAlpha:      "Alphabetisch",
+	ConfigOrderAlphaHint:  "(nach Gruppe, dann nach Name)",
+	ConfigOrderChoice:     "Auswahl [1/2] (Standard: 1): ",
+	ConfigOrderConfirm:    "Reihenfolge: %s",
+	ConfigOrderInvalid:    "Ungültige Auswahl. Gib 1 oder 2 ein: ",
 }
@@ -3,19 +3,33 @@ package i18n
 var messagesEN = Messages{
 	// main.go
 	ErrConfig:          "Error: configuration error: %v",
-	ErrNoPackageJSON:   "Error: no package.json found.",
+	ErrNoPackageJSON:   "Error: no project file found (package.json, deno.json, composer.json, pyproject.toml, Makefile, justfile or Taskfile).",
 	PackageJSONFound:   "package.json found: %s",
-	ErrReadPackageJSON: "Error: cannot read package.json: %v",
+	ErrReadPackageJSON: "Error: cannot read %s: %v",
 	ErrNoScripts:       "Error: no scripts found in package.json.",
 	Cancelled:          "Cancelled.",
 	Executing:          "Running: %s %s",
+	ExecutingParallel:  "Running in parallel: %s",
 	ErrCommandFailed:   "Error: command failed: %v",
+	ErrScriptExited:    "Error: '%s' exited with code %d.",
 	Success:            "Completed successfully.",
+	ParallelSummary:    "Summary:",
+	ParallelKilled:     "killed",
+	ParallelFailed:     "%d of %d failed.",
+	ParallelSkipped:    "skipped",
+	ParallelNotRun:     "%d of %d not run.",
 	ErrGeneric:         "Error: %v",
 	ErrSaveConfig:      "Error: unable to save configuration: %v",
 	ErrReadHistory:     "Error: unable to read history: %v",
 	HistoryEmpty:       "No commands in history.",
 	HistoryTitle:       "Script execution history",
+	HistoryHint:        "del delete",
+	HistoryRemove:      "Delete %s from the history? [y/N]",
+	HistoryCount:       "(%d/%d entries)",
+	HistoryPrompt:      "Entry number to re-run (or q to quit): ",
+	NoMatchingEntries:  "(no matching entries)",
+	ErrNoHistoryEntry:  "Error: no history entry #%s.",
+	ErrNothingToReplay: "Error: nothing to re-run in this project yet.",
 	TimeJustNow:        "just now",
 	TimeMinutesAgo:     "%dm ago",
 	TimeHoursAgo:       "%dh ago",
@@ -24,9 +38,36 @@ var messagesEN = Messages{
 	VersionFormat:      "skit version %s",
 	DetectedRunner:     "Detected: %s",
 	ContextLine:        "%s  ▸  %s",
+	RunnerConflict:     "Warning: conflicting package manager hints: %s. Run skit --which for details.",
+	HooksNotSkipped:    "Warning: %s cannot skip the pre/post hooks of %s; they run too.",
+	ConfirmRun:         "Run %s? [y/N] ",
+	ConfirmYes:         "y yes",
+	ErrCwdOutside:      "the x-skit cwd %q of %s is not a directory inside the package",
+	WhichRunner:        "Runner: %s (%s)",
+	WhichPackage:       "Package: %s",
+	WhichBerry:         "Yarn Berry (2+), with node_modules.",
+	WhichBerryPnP:      "Yarn Berry (2+), with Plug'n'Play: no node_modules.",
+	WhichDeclared:      "Chosen because %s declares it.",
+	WhichLockfile:      "Chosen because of %s; no package.json declares a package manager.",
+	WhichSource:        "Chosen because the tasks come from %s.",
+	WhichDefault:       "No packageManager field or lockfile found: npm is the default.",
+	WhichPriority:      "Order: packageManager, devEngines.packageManager, then lockfiles (bun > pnpm > yarn > npm); the package before the monorepo root.",
+	WhichEvidence:      "Found:",
+	WhichConflict:      "conflict",
+	WhichRoot:          "monorepo root",
 	WorkspaceDetected:  "Workspaces detected (%d packages)",
 	WorkspacePrompt:    "Workspace number (or q to quit): ",
-	WorkspaceInvalid:   "Invalid choice. Number between 1 and %d (or q): ",
+	WorkspaceScript:    "%d script",
+	WorkspaceScripts:   "%d scripts",
+	WorkspaceCountFmt:  "(%d/%d packages)",
+	NoMatchingPackages: "No matching packages.",
+	ErrNoWorkspaces:    "Error: no workspaces found in this project.",
+	ErrNoWorkspaceRun:  "Error: no workspace defines a '%s' script.",
+	ErrNoFilterMatch:   "Error: no workspace package matches --filter %s.",
+	ChangedPackages:    "Changed since %s: %s",
+	NoChangedPackages:  "No workspace package changed since %s.",
+	ExecutingAll:       "Running %s in %d packages",
+	WorkspaceCount:     "in %d packages",
 	UsingRoot:          "Using root package.json",
 
 	// ui/menu.go
@@ -35,6 +76,10 @@ var messagesEN = Messages{
 	FilterActiveLabel: "Active filter: ",
 	NoMatchingScripts: "(no matching scripts)",
 	ScriptCount:       "(%d/%d scripts)",
+	MenuRecent:        "Recent",
+	MenuAllScripts:    "All scripts",
+	MenuDependsOn:     "after %s",
+	MenuMultiHint:     "space multi-select",
 	HelpArrows:        "↑/↓ navigate  •  / filter  •  enter select  •  q quit",
 	HelpWASD:          "↑/↓/w/s navigate  •  / filter  •  enter select  •  q quit",
 	HelpCustomFmt:     "↑/↓/%s/%s navigate  •  / filter  •  enter select  •  %s",
@@ -73,4 +118,14 @@ var messagesEN = Messages{
 	ConfigColorChoice:           "Choice [1/2/3/4] (default: 1): ",
 	ConfigColorConfirm:          "Colors: %s",
 	ConfigColorInvalid:          "Invalid choice. Enter 1, 2, 3 or 4: ",
+
+	// config.go — script order
+	ConfigOrderPrompt:     "How should scripts be ordered in the menu?",
+	ConfigOrderRecent:     "Recent first",
+	ConfigOrderRecentHint: "(pin your most used scripts at the top)",
+	ConfigOrderAlpha:      "Alphabetical",
+	ConfigOrderAlphaHint:  "(by group, then name)",
+	ConfigOrderChoice:     "Choice [1/2] (default: 1): ",
+	ConfigOrderConfirm:    "Order: %s",
+	ConfigOrderInvalid:    "Invalid choice. Enter 1 or 2: ",
 }
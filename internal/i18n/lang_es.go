@@ -3,19 +3,33 @@ package i18n
 var messagesES = Messages{
 	// main.go
 	ErrConfig:          "Error de configuración: %v",
-	ErrNoPackageJSON:   "Error: no se encontró ningún package.json.",
+	ErrNoPackageJSON:   "Error: no se encontró ningún archivo de proyecto (package.json, deno.json, composer.json, pyproject.toml, Makefile, justfile o Taskfile).",
 	PackageJSONFound:   "package.json encontrado: %s",
-	ErrReadPackageJSON: "Error: no se puede leer package.json: %v",
+	ErrReadPackageJSON: "Error: no se puede leer %s: %v",
 	ErrNoScripts:       "Error: no se encontraron scripts en package.json.",
 	Cancelled:          "Cancelado.",
 	Executing:          "Ejecutando: %s %s",
+	ExecutingParallel:  "Ejecutando en paralelo: %s",
 	ErrCommandFailed:   "Error: el comando falló: %v",
+	ErrScriptExited:    "Error: '%s' terminó con el código %d.",
 	Success:            "Completado con éxito.",
+	ParallelSummary:    "Resumen:",
+	ParallelKilled:     "detenido",
+	ParallelFailed:     "%d de %d fallaron.",
+	ParallelSkipped:    "omitido",
+	ParallelNotRun:     "%d de %d sin ejecutar.",
 	ErrGeneric:         "Error: %v",
 	ErrSaveConfig:      "Error: no se pudo guardar la configuración: %v",
 	ErrReadHistory:     "Error: no se pudo leer el historial: %v",
 	HistoryEmpty:       "No hay comandos en el historial.",
 	HistoryTitle:       "Historial de scripts",
+	HistoryHint:        "supr eliminar",
+	HistoryRemove:      "¿Eliminar %s del historial? [s/N]",
+	HistoryCount:       "(%d/%d entradas)",
+	HistoryPrompt:      "Número de la entrada a reejecutar (o q para salir): ",
+	NoMatchingEntries:  "(ninguna entrada coincidente)",
+	ErrNoHistoryEntry:  "Error: no existe la entrada #%s en el historial.",
+	ErrNothingToReplay: "Error: todavía no hay nada que volver a ejecutar en este proyecto.",
 	TimeJustNow:        "ahora mismo",
 	TimeMinutesAgo:     "hace %dm",
 	TimeHoursAgo:       "hace %dh",
@@ -24,9 +38,36 @@ var messagesES = Messages{
 	VersionFormat:      "skit version %s",
 	DetectedRunner:     "Detectado: %s",
 	ContextLine:        "%s  ▸  %s",
+	RunnerConflict:     "Aviso: indicios de gestor de paquetes contradictorios: %s. Ejecuta skit --which para más detalles.",
+	HooksNotSkipped:    "Aviso: %s no puede omitir los hooks pre/post de %s; también se ejecutarán.",
+	ConfirmRun:         "¿Ejecutar %s? [s/N] ",
+	ConfirmYes:         "s si sí y yes",
+	ErrCwdOutside:      "el cwd de x-skit %q de %s no es un directorio dentro del paquete",
+	WhichRunner:        "Runner: %s (%s)",
+	WhichPackage:       "Paquete: %s",
+	WhichBerry:         "Yarn Berry (2+), con node_modules.",
+	WhichBerryPnP:      "Yarn Berry (2+), con Plug'n'Play: sin node_modules.",
+	WhichDeclared:      "Elegido porque %s lo declara.",
+	WhichLockfile:      "Elegido por %s; ningún package.json declara un gestor de paquetes.",
+	WhichSource:        "Elegido porque las tareas vienen de %s.",
+	WhichDefault:       "No se encontró campo packageManager ni lockfile: npm por defecto.",
+	WhichPriority:      "Orden: packageManager, devEngines.packageManager, luego lockfiles (bun > pnpm > yarn > npm); el paquete antes de la raíz del monorepo.",
+	WhichEvidence:      "Encontrado:",
+	WhichConflict:      "conflicto",
+	WhichRoot:          "raíz del monorepo",
 	WorkspaceDetected:  "Workspaces detectados (%d paquetes)",
 	WorkspacePrompt:    "Número del workspace (o q para salir): ",
-	WorkspaceInvalid:   "Opción inválida. Número entre 1 y %d (o q): ",
+	WorkspaceScript:    "%d script",
+	WorkspaceScripts:   "%d scripts",
+	WorkspaceCountFmt:  "(%d/%d paquetes)",
+	NoMatchingPackages: "Ningún paquete coincide.",
+	ErrNoWorkspaces:    "Error: no se encontraron workspaces en este proyecto.",
+	ErrNoWorkspaceRun:  "Error: ningún workspace define un script '%s'.",
+	ErrNoFilterMatch:   "Error: ningún paquete del workspace coincide con --filter %s.",
+	ChangedPackages:    "Modificados desde %s: %s",
+	NoChangedPackages:  "Ningún paquete del workspace cambió desde %s.",
+	ExecutingAll:       "Ejecutando %s en %d paquetes",
+	WorkspaceCount:     "en %d paquetes",
 	UsingRoot:          "Usando package.json raíz",
 
 	// ui/menu.go
@@ -35,6 +76,10 @@ var messagesES = Messages{
 	FilterActiveLabel: "Filtro activo: ",
 	NoMatchingScripts: "(ningún script coincidente)",
 	ScriptCount:       "(%d/%d scripts)",
+	MenuRecent:        "Recientes",
+	MenuAllScripts:    "Todos los scripts",
+	MenuDependsOn:     "después de %s",
+	MenuMultiHint:     "espacio selección múltiple",
 	HelpArrows:        "↑/↓ navegar  •  / filtrar  •  enter seleccionar  •  q salir",
 	HelpWASD:          "↑/↓/w/s navegar  •  / filtrar  •  enter seleccionar  •  q salir",
 	HelpCustomFmt:     "↑/↓/%s/%s navegar  •  / filtrar  •  enter seleccionar  •  %s",
@@ -73,4 +118,14 @@ var messagesES = Messages{
 	ConfigColorChoice:           "Opción [1/2/3/4] (por defecto: 1): ",
 	ConfigColorConfirm:          "Colores: %s",
 	ConfigColorInvalid:          "Opción inválida. Introduce 1, 2, 3 o 4: ",
+
+	// config.go — script order
+	ConfigOrderPrompt:     "¿Cómo ordenar los scripts en el menú?",
+	ConfigOrderRecent:     "Recientes primero",
+	ConfigOrderRecentHint: "(fija arriba tus scripts más usados)",
+	ConfigOrderAlpha:      "Alfabético",
+	ConfigOrderAlphaHint:  "(por grupo y luego por nombre)",
+	ConfigOrderChoice:     "Opción [1/2] (predeterminado: 1): ",
+	ConfigOrderConfirm:    "Orden: %s",
+	ConfigOrderInvalid:    "Opción inválida. Introduce 1 o 2: ",
 }
@@ -3,19 +3,33 @@ package i18n
 var messagesFR = Messages{
 	// main.go
 	ErrConfig:          "Erreur de configuration : %v",
-	ErrNoPackageJSON:   "Erreur : aucun package.json trouvé.",
+	ErrNoPackageJSON:   "Erreur : aucun fichier de projet trouvé (package.json, deno.json, composer.json, pyproject.toml, Makefile, justfile ou Taskfile).",
 	PackageJSONFound:   "package.json trouvé : %s",
-	ErrReadPackageJSON: "Erreur : impossible de lire package.json : %v",
+	ErrReadPackageJSON: "Erreur : impossible de lire %s : %v",
 	ErrNoScripts:       "Erreur : aucun script trouvé dans package.json.",
 	Cancelled:          "Annulé.",
 	Executing:          "Exécution : %s %s",
+	ExecutingParallel:  "Exécution en parallèle : %s",
 	ErrCommandFailed:   "Erreur : la commande a échoué : %v",
+	ErrScriptExited:    "Erreur : '%s' s'est terminé avec le code %d.",
 	Success:            "Terminé avec succès.",
+	ParallelSummary:    "Résumé :",
+	ParallelKilled:     "arrêté",
+	ParallelFailed:     "%d sur %d en échec.",
+	ParallelSkipped:    "ignoré",
+	ParallelNotRun:     "%d sur %d non exécuté(s).",
 	ErrGeneric:         "Erreur : %v",
 	ErrSaveConfig:      "Erreur : impossible de sauvegarder la configuration : %v",
 	ErrReadHistory:     "Erreur : impossible de lire l'historique : %v",
 	HistoryEmpty:       "Aucune commande dans l'historique.",
 	HistoryTitle:       "Historique des scripts",
+	HistoryHint:        "suppr supprimer",
+	HistoryRemove:      "Supprimer %s de l'historique ? [o/N]",
+	HistoryCount:       "(%d/%d entrées)",
+	HistoryPrompt:      "Numéro de l'entrée à relancer (ou q pour quitter) : ",
+	NoMatchingEntries:  "(aucune entrée correspondante)",
+	ErrNoHistoryEntry:  "Erreur : aucune entrée n°%s dans l'historique.",
+	ErrNothingToReplay: "Erreur : rien à relancer dans ce projet pour l'instant.",
 	TimeJustNow:        "à l'instant",
 	TimeMinutesAgo:     "il y a %dm",
 	TimeHoursAgo:       "il y a %dh",
@@ -24,9 +38,36 @@ var messagesFR = Messages{
 	VersionFormat:      "skit version %s",
 	DetectedRunner:     "Détecté : %s",
 	ContextLine:        "%s  ▸  %s",
+	RunnerConflict:     "Attention : indices de gestionnaire de paquets contradictoires : %s. Lancez skit --which pour les détails.",
+	HooksNotSkipped:    "Attention : %s ne peut pas ignorer les hooks pre/post de %s ; ils seront exécutés aussi.",
+	ConfirmRun:         "Lancer %s ? [o/N] ",
+	ConfirmYes:         "o oui y yes",
+	ErrCwdOutside:      "le cwd x-skit %q de %s n'est pas un dossier du paquet",
+	WhichRunner:        "Runner : %s (%s)",
+	WhichPackage:       "Package : %s",
+	WhichBerry:         "Yarn Berry (2+), avec node_modules.",
+	WhichBerryPnP:      "Yarn Berry (2+), avec Plug'n'Play : pas de node_modules.",
+	WhichDeclared:      "Choisi car %s le déclare.",
+	WhichLockfile:      "Choisi à cause de %s ; aucun package.json ne déclare de gestionnaire de paquets.",
+	WhichSource:        "Choisi car les tâches viennent de %s.",
+	WhichDefault:       "Aucun champ packageManager ni lockfile trouvé : npm par défaut.",
+	WhichPriority:      "Ordre : packageManager, devEngines.packageManager, puis les lockfiles (bun > pnpm > yarn > npm) ; le package avant la racine du monorepo.",
+	WhichEvidence:      "Trouvé :",
+	WhichConflict:      "conflit",
+	WhichRoot:          "racine du monorepo",
 	WorkspaceDetected:  "Workspaces détectés (%d packages)",
 	WorkspacePrompt:    "Numéro du workspace (ou q pour quitter) : ",
-	WorkspaceInvalid:   "Choix invalide. Numéro entre 1 et %d (ou q) : ",
+	WorkspaceScript:    "%d script",
+	WorkspaceScripts:   "%d scripts",
+	WorkspaceCountFmt:  "(%d/%d packages)",
+	NoMatchingPackages: "Aucun package correspondant.",
+	ErrNoWorkspaces:    "Erreur : aucun workspace trouvé dans ce projet.",
+	ErrNoWorkspaceRun:  "Erreur : aucun workspace ne définit de script '%s'.",
+	ErrNoFilterMatch:   "Erreur : aucun package du workspace ne correspond à --filter %s.",
+	ChangedPackages:    "Modifiés depuis %s : %s",
+	NoChangedPackages:  "Aucun package du workspace modifié depuis %s.",
+	ExecutingAll:       "Exécution de %s dans %d packages",
+	WorkspaceCount:     "dans %d packages",
 	UsingRoot:          "Utilisation du package.json racine",
 
 	// ui/menu.go
@@ -35,6 +76,10 @@ var messagesFR = Messages{
 	FilterActiveLabel: "Filtre actif : ",
 	NoMatchingScripts: "(aucun script correspondant)",
 	ScriptCount:       "(%d/%d scripts)",
+	MenuRecent:        "Récents",
+	MenuAllScripts:    "Tous les scripts",
+	MenuDependsOn:     "après %s",
+	MenuMultiHint:     "espace sélection multiple",
 	HelpArrows:        "↑/↓ naviguer  •  / filtrer  •  enter sélectionner  •  q quitter",
 	HelpWASD:          "↑/↓/w/s naviguer  •  / filtrer  •  enter sélectionner  •  q quitter",
 	HelpCustomFmt:     "↑/↓/%s/%s naviguer  •  / filtrer  •  enter sélectionner  •  %s",
@@ -73,4 +118,14 @@ var messagesFR = Messages{
 	ConfigColorChoice:           "Choix [1/2/3/4] (défaut : 1) : ",
 	ConfigColorConfirm:          "Couleurs : %s",
 	ConfigColorInvalid:          "Choix invalide. Entre 1, 2, 3 ou 4 : ",
+
+	// config.go — script order
+	ConfigOrderPrompt:     "Comment ordonner les scripts dans le menu ?",
+	ConfigOrderRecent:     "Récents d'abord",
+	ConfigOrderRecentHint: "(épingle tes scripts les plus utilisés en haut)",
+	ConfigOrderAlpha:      "Alphabétique",
+	ConfigOrderAlphaHint:  "(par groupe, puis par nom)",
+	ConfigOrderChoice:     "Choix [1/2] (défaut : 1) : ",
+	ConfigOrderConfirm:    "Ordre : %s",
+	ConfigOrderInvalid:    "Choix invalide. Entre 1 ou 2 : ",
 }
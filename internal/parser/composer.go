@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// parseComposer reads the scripts of a composer.json, with their
+// descriptions from the scripts-descriptions field. A script is a command or
+// a list of commands.
+func parseComposer(path string) ([]Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Scripts      map[string]json.RawMessage `json:"scripts"`
+		Descriptions map[string]string          `json:"scripts-descriptions"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil, err
+	}
+
+	scripts := make([]Script, 0, len(pkg.Scripts))
+	for name, raw := range pkg.Scripts {
+		s := Script{Name: name, Description: pkg.Descriptions[name]}
+		var cmds []string
+		if err := json.Unmarshal(raw, &s.Command); err != nil {
+			_ = json.Unmarshal(raw, &cmds)
+			s.Command = strings.Join(cmds, "; ")
+		}
+		scripts = append(scripts, s)
+	}
+	return scripts, nil
+}
@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// denoConfigNames are the Deno configuration files, by precedence.
+var denoConfigNames = []string{"deno.json", "deno.jsonc"}
+
+// denoTask is a task of deno.json: a command, or an object with a command,
+// a description and tasks to run first.
+type denoTask struct {
+	Command      string   `json:"command"`
+	Description  string   `json:"description"`
+	Dependencies []string `json:"dependencies"`
+}
+
+func (t *denoTask) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Command); err == nil {
+		return nil
+	}
+	type object denoTask
+	return json.Unmarshal(data, (*object)(t))
+}
+
+// parseDeno reads the tasks of a deno.json or deno.jsonc file.
+func parseDeno(path string) ([]Script, error) {
+	data, err := readJSONC(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Tasks map[string]denoTask `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	scripts := make([]Script, 0, len(cfg.Tasks))
+	for name, task := range cfg.Tasks {
+		scripts = append(scripts, Script{
+			Name:         name,
+			Command:      task.Command,
+			Description:  task.Description,
+			Dependencies: task.Dependencies,
+		})
+	}
+	return scripts, nil
+}
+
+// hasDenoTasks reports whether a Deno config file defines any task.
+func hasDenoTasks(path string) bool {
+	scripts, err := parseDeno(path)
+	return err == nil && len(scripts) > 0
+}
+
+// readJSONC reads a JSON file that may contain comments and trailing commas,
+// as deno.jsonc does, and returns it as plain JSON.
+func readJSONC(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return stripJSONC(data), nil
+}
+
+// stripJSONC removes // and /* */ comments and trailing commas outside
+// strings. Comments are replaced by spaces, keeping offsets in error
+// messages meaningful.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				out = append(out, ' ')
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(data) - i - 2
+			} else {
+				end += 2
+			}
+			for _, b := range data[i : i+2+end] {
+				if b == '\n' {
+					out = append(out, '\n')
+				} else {
+					out = append(out, ' ')
+				}
+			}
+			i += 1 + end
+		case c == ']' || c == '}':
+			// Drop a comma followed only by whitespace before the bracket.
+			j := len(out) - 1
+			for j >= 0 && isJSONSpace(out[j]) {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out[j] = ' '
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
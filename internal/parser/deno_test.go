@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestStripJSONC(t *testing.T) {
+	in := `{
+  // line comment
+  "url": "https://deno.land/x", /* block
+  comment */ "glob": "src/**/*.ts",
+  "list": [1, 2,],
+  "escaped": "a \" // not a comment",
+}`
+	var got map[string]any
+	if err := json.Unmarshal(stripJSONC([]byte(in)), &got); err != nil {
+		t.Fatalf("stripped JSONC does not parse: %v", err)
+	}
+	if got["url"] != "https://deno.land/x" || got["glob"] != "src/**/*.ts" || got["escaped"] != `a " // not a comment` {
+		t.Errorf("unexpected values: %v", got)
+	}
+	if list, _ := got["list"].([]any); len(list) != 2 {
+		t.Errorf("list = %v, want 2 items", got["list"])
+	}
+}
+
+func TestParseDenoTasks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "deno.jsonc")
+	content := `{
+  "name": "@acme/api",
+  "tasks": {
+    // plain command
+    "dev": "deno run --watch main.ts",
+    "build": {
+      "description": "Bundle the service",
+      "command": "deno compile main.ts",
+      "dependencies": ["check", "test"],
+    },
+    "check": "deno check main.ts",
+    "test": "deno test",
+    "test:watch": "deno test --watch",
+    "ci": { "dependencies": ["build"] },
+  },
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range scripts {
+		names = append(names, s.Name)
+	}
+	if want := []string{"build", "check", "ci", "dev", "test", "test:watch"}; !slices.Equal(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+
+	build := scripts[0]
+	if build.Command != "deno compile main.ts" || build.Description != "Bundle the service" {
+		t.Errorf("build = %+v", build)
+	}
+	if !slices.Equal(build.Dependencies, []string{"check", "test"}) {
+		t.Errorf("build dependencies = %v, want [check test]", build.Dependencies)
+	}
+	if ci := scripts[2]; ci.Command != "" || !slices.Equal(ci.Dependencies, []string{"build"}) {
+		t.Errorf("ci = %+v", ci)
+	}
+	if scripts[4].Group != "test" {
+		t.Errorf("test group = %q, want test", scripts[4].Group)
+	}
+	if got := ParseName(path); got != "@acme/api" {
+		t.Errorf("ParseName = %q, want @acme/api", got)
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tasks := `{"tasks": {"dev": "deno run main.ts"}}`
+
+	t.Run("deno only", func(t *testing.T) {
+		dir := t.TempDir()
+		sub := filepath.Join(dir, "src")
+		os.MkdirAll(sub, 0755)
+		write(filepath.Join(dir, "deno.json"), tasks)
+		if got, want := FindProject(sub), filepath.Join(dir, "deno.json"); got != want {
+			t.Errorf("FindProject = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("package.json with scripts wins", func(t *testing.T) {
+		dir := t.TempDir()
+		write(filepath.Join(dir, "deno.json"), tasks)
+		write(filepath.Join(dir, "package.json"), `{"scripts": {"dev": "vite"}}`)
+		if got, want := FindProject(dir), filepath.Join(dir, "package.json"); got != want {
+			t.Errorf("FindProject = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("package.json without scripts", func(t *testing.T) {
+		dir := t.TempDir()
+		write(filepath.Join(dir, "deno.jsonc"), tasks)
+		write(filepath.Join(dir, "package.json"), `{"dependencies": {"chalk": "^5.0.0"}}`)
+		if got, want := FindProject(dir), filepath.Join(dir, "deno.jsonc"); got != want {
+			t.Errorf("FindProject = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("deno.json without tasks", func(t *testing.T) {
+		dir := t.TempDir()
+		write(filepath.Join(dir, "deno.json"), `{"imports": {}}`)
+		if got := FindProject(dir); got != "" {
+			t.Errorf("FindProject = %q, want none", got)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// workspacePattern is a compiled workspace glob, split into path segments.
+type workspacePattern struct {
+	segments []string
+	negated  bool
+}
+
+// compileWorkspacePattern normalizes a pattern as npm, yarn and pnpm do:
+// "./" prefixes, trailing slashes and a trailing "/package.json" are
+// ignored, and a leading "!" makes it an exclusion.
+func compileWorkspacePattern(pattern string) (workspacePattern, bool) {
+	var p workspacePattern
+	pattern = strings.TrimSpace(filepath.ToSlash(pattern))
+	if rest, ok := strings.CutPrefix(pattern, "!"); ok {
+		p.negated, pattern = true, rest
+	}
+	pattern = strings.TrimSuffix(pattern, "/package.json")
+	pattern = path.Clean("/" + pattern)[1:]
+	if pattern == "" {
+		return p, false
+	}
+	p.segments = strings.Split(pattern, "/")
+	for _, seg := range p.segments {
+		if _, err := path.Match(seg, ""); err != nil {
+			return p, false
+		}
+	}
+	return p, true
+}
+
+// matchWorkspaceDirs returns the directories under rootDir, relative to it
+// and slash-separated, that match any of the patterns and none of the
+// negated ones, in walk order. Patterns support "*", "?", "[...]" within a
+// segment and "**" for any number of segments. Exclusions apply whatever
+// their position in the list. node_modules and .git are never entered;
+// symlinks to directories are, unless they lead back to a directory being
+// walked.
+func matchWorkspaceDirs(rootDir string, patterns []string) []string {
+	var include, exclude []workspacePattern
+	for _, raw := range patterns {
+		p, ok := compileWorkspacePattern(raw)
+		switch {
+		case !ok:
+		case p.negated:
+			exclude = append(exclude, p)
+		default:
+			include = append(include, p)
+		}
+	}
+	if len(include) == 0 {
+		return nil
+	}
+
+	w := dirWalker{include: include, exclude: exclude, active: make(map[string]bool)}
+	w.walk(rootDir, nil)
+	return w.dirs
+}
+
+// dirWalker walks the directories of a workspace root, following symlinks to
+// directories as filepath.Glob does.
+type dirWalker struct {
+	include, exclude []workspacePattern
+	active           map[string]bool // real paths of the directories being walked, to stop at cycles
+	dirs             []string
+}
+
+// walk matches the subdirectories of dir, found at segments under the root,
+// and walks those that could contain a match.
+func (w *dirWalker) walk(dir string, segments []string) {
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil || w.active[real] {
+		return
+	}
+	w.active[real] = true
+	defer delete(w.active, real)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, d := range entries {
+		name := d.Name()
+		if name == "node_modules" || name == ".git" {
+			continue
+		}
+		p := filepath.Join(dir, name)
+		isDir := d.IsDir()
+		if d.Type()&fs.ModeSymlink != 0 {
+			info, err := os.Stat(p)
+			isDir = err == nil && info.IsDir()
+			// A link back to a directory being walked is a cycle.
+			if target, err := filepath.EvalSymlinks(p); err != nil || w.active[target] {
+				continue
+			}
+		}
+		if !isDir {
+			continue
+		}
+
+		sub := append(slices.Clip(segments), name)
+		if matchAny(w.include, sub) && !matchAny(w.exclude, sub) {
+			w.dirs = append(w.dirs, strings.Join(sub, "/"))
+		}
+		for _, inc := range w.include {
+			if canContainMatch(inc.segments, sub) {
+				w.walk(p, sub)
+				break
+			}
+		}
+	}
+}
+
+func matchAny(patterns []workspacePattern, segments []string) bool {
+	for _, p := range patterns {
+		if matchSegments(p.segments, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether a path matches a pattern, segment by segment.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		// Zero segments, or one more and try again.
+		return matchSegments(pattern[1:], segments) ||
+			(len(segments) > 0 && matchSegments(pattern, segments[1:]))
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	ok, _ := path.Match(pattern[0], segments[0])
+	return ok && matchSegments(pattern[1:], segments[1:])
+}
+
+// canContainMatch reports whether a directory could have a descendant
+// matching pattern, so that walking it is worth it.
+func canContainMatch(pattern, segments []string) bool {
+	if len(segments) == 0 {
+		return len(pattern) > 0
+	}
+	if len(pattern) == 0 {
+		return false
+	}
+	if pattern[0] == "**" {
+		return true
+	}
+	ok, _ := path.Match(pattern[0], segments[0])
+	return ok && canContainMatch(pattern[1:], segments[1:])
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// globTree creates a package.json in each of the given directories.
+func globTree(t *testing.T, dirs ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range dirs {
+		path := filepath.Join(root, filepath.FromSlash(dir), "package.json")
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(`{}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestMatchWorkspaceDirs(t *testing.T) {
+	root := globTree(t,
+		"apps/web",
+		"apps/web/e2e",
+		"packages/ui",
+		"packages/legacy",
+		"packages/tools/cli",
+		"packages/tools/cli/test/fixture",
+		"packages/ui/node_modules/react",
+		"node_modules/left-pad",
+	)
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		{"npm/yarn single level", []string{"apps/*", "packages/*"},
+			[]string{"apps/web", "packages/legacy", "packages/tools", "packages/ui"}},
+		{"leading ./ and trailing slash", []string{"./apps/*/"},
+			[]string{"apps/web"}},
+		{"trailing package.json", []string{"packages/*/package.json"},
+			[]string{"packages/legacy", "packages/tools", "packages/ui"}},
+		{"exact directory", []string{"packages/tools/cli"},
+			[]string{"packages/tools/cli"}},
+		{"recursive, without node_modules", []string{"packages/**"},
+			[]string{"packages", "packages/legacy", "packages/tools", "packages/tools/cli", "packages/tools/cli/test", "packages/tools/cli/test/fixture", "packages/ui"}},
+		{"npm negation", []string{"packages/*", "!packages/legacy"},
+			[]string{"packages/tools", "packages/ui"}},
+		{"negation before the pattern it excludes from", []string{"!packages/legacy", "packages/*"},
+			[]string{"packages/tools", "packages/ui"}},
+		{"pnpm recursive exclusion", []string{"**", "!**/test/**", "!packages"},
+			[]string{"apps", "apps/web", "apps/web/e2e", "packages/legacy", "packages/tools", "packages/tools/cli", "packages/ui"}},
+		{"invalid patterns are ignored", []string{"[a-", "apps/*"},
+			[]string{"apps/web"}},
+		{"only exclusions", []string{"!packages/legacy"}, nil},
+	}
+	for _, tt := range tests {
+		got := matchWorkspaceDirs(root, tt.patterns)
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: matchWorkspaceDirs(%q) = %v, want %v", tt.name, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestMatchWorkspaceDirsFollowsSymlinks(t *testing.T) {
+	root := globTree(t, "packages/ui", "vendor/linked/src")
+	link := func(target, name string) {
+		t.Helper()
+		if err := os.Symlink(target, filepath.Join(root, name)); err != nil {
+			t.Skipf("symlinks unavailable: %v", err)
+		}
+	}
+	link(filepath.Join(root, "vendor", "linked"), "packages/linked")
+	// A link back to an ancestor must not loop forever.
+	link(root, "vendor/linked/src/loop")
+
+	if got, want := matchWorkspaceDirs(root, []string{"packages/*"}), []string{"packages/linked", "packages/ui"}; !slices.Equal(got, want) {
+		t.Errorf("matchWorkspaceDirs(packages/*) = %v, want %v", got, want)
+	}
+	got := matchWorkspaceDirs(root, []string{"packages/**"})
+	want := []string{"packages", "packages/linked", "packages/linked/src", "packages/ui"}
+	if !slices.Equal(got, want) {
+		t.Errorf("matchWorkspaceDirs(packages/**) = %v, want %v", got, want)
+	}
+}
+
+func TestParseWorkspacesGlobs(t *testing.T) {
+	root := globTree(t, "packages/a", "packages/nested/b", "packages/legacy", "packages/a/node_modules/dep")
+	pkg := filepath.Join(root, "package.json")
+	content := `{"workspaces": {"packages": ["packages/**", "!packages/legacy"]}}`
+	if err := os.WriteFile(pkg, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	for _, ws := range ParseWorkspaces(pkg) {
+		paths = append(paths, filepath.ToSlash(ws.Path))
+	}
+	want := []string{"packages/a", "packages/nested/b"}
+	if !slices.Equal(paths, want) {
+		t.Errorf("ParseWorkspaces paths = %v, want %v", paths, want)
+	}
+}
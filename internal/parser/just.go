@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"os"
+	"strings"
+)
+
+// parseJustfile reads the public recipes of a justfile. A recipe is
+// described by its doc comment, the comment line right above it, or by a
+// [doc("...")] attribute. Private recipes, named with a leading underscore or
+// marked [private], are skipped.
+func parseJustfile(path string) ([]Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []Script
+	var doc, docAttr string
+	private := false
+	current := -1 // recipe whose body is being read
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && (line[0] == ' ' || line[0] == '\t') {
+			// Indented: a recipe body line.
+			if current >= 0 && !strings.HasPrefix(trimmed, "#") {
+				scripts[current].Command = joinCommand(scripts[current].Command, strings.TrimLeft(trimmed, "@-"))
+			}
+			continue
+		}
+		switch {
+		case trimmed == "":
+			doc = ""
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			doc = strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+			current = -1
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			for _, attr := range strings.Split(strings.Trim(trimmed, "[]"), ",") {
+				attr = strings.TrimSpace(attr)
+				if attr == "private" {
+					private = true
+				} else if rest, ok := strings.CutPrefix(attr, "doc("); ok {
+					docAttr = strings.Trim(strings.TrimSuffix(rest, ")"), `"'`)
+				}
+			}
+			current = -1
+			continue
+		}
+
+		current = -1
+		name, deps, ok := justRecipe(trimmed)
+		if ok && !private && !strings.HasPrefix(name, "_") {
+			desc := docAttr
+			if desc == "" {
+				desc = doc
+			}
+			current = len(scripts)
+			scripts = append(scripts, Script{Name: name, Description: desc, Dependencies: deps})
+		}
+		doc, docAttr, private = "", "", false
+	}
+	return scripts, nil
+}
+
+// justKeywords start justfile lines that are not recipes.
+var justKeywords = []string{"alias", "set", "export", "import", "mod"}
+
+// justRecipe parses a recipe header, "name params: dependencies", returning
+// its name and the recipes it depends on.
+func justRecipe(line string) (name string, deps []string, ok bool) {
+	line = strings.TrimPrefix(line, "@")
+	end := strings.IndexFunc(line, func(r rune) bool {
+		return !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end <= 0 {
+		return "", nil, false
+	}
+	name = line[:end]
+	for _, kw := range justKeywords {
+		if name == kw && end < len(line) && line[end] == ' ' {
+			return "", nil, false
+		}
+	}
+
+	// The colon ending the header is outside quotes and not part of ":=".
+	var quote byte
+	colon := -1
+	for i := end; i < len(line) && colon < 0; i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ':':
+			if i+1 < len(line) && line[i+1] == '=' {
+				return "", nil, false
+			}
+			colon = i
+		case c == '=' && i == end:
+			return "", nil, false
+		}
+	}
+	if colon < 0 {
+		return "", nil, false
+	}
+
+	// Dependencies after "&&" run after the recipe, and those in
+	// parentheses take arguments.
+	rest, _, _ := strings.Cut(line[colon+1:], "&&")
+	if i := strings.Index(rest, "#"); i >= 0 {
+		rest = rest[:i]
+	}
+	for _, dep := range strings.Fields(rest) {
+		if strings.ContainsAny(dep, `()"'`) {
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	return name, deps, true
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"os"
+	"strings"
+)
+
+// parseMakefile reads the targets of a Makefile. A target is described by a
+// "## text" comment at the end of its rule line, or on the lines right above
+// it. Special targets, pattern rules and file-like targets are skipped.
+func parseMakefile(path string) ([]Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []Script
+	index := make(map[string]int)
+	var doc []string  // "##" lines above the next rule
+	var current []int // targets of the rule whose recipe is being read
+	inDefine := false
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if inDefine {
+			inDefine = trimmed != "endef"
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			if cmd := strings.TrimLeft(trimmed, "@-+"); cmd != "" && !strings.HasPrefix(cmd, "#") {
+				for _, i := range current {
+					scripts[i].Command = joinCommand(scripts[i].Command, cmd)
+				}
+			}
+			continue
+		}
+		if rest, ok := strings.CutPrefix(trimmed, "##"); ok {
+			doc = append(doc, strings.TrimSpace(rest))
+			current = nil
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			doc = nil
+			continue
+		}
+		current = nil
+		if strings.HasPrefix(trimmed, "define ") || trimmed == "define" {
+			inDefine, doc = true, nil
+			continue
+		}
+
+		targets, desc, ok := makeRule(line)
+		if !ok {
+			doc = nil
+			continue
+		}
+		if desc == "" {
+			desc = strings.Join(doc, " ")
+		}
+		doc = nil
+		for _, name := range targets {
+			i, seen := index[name]
+			if !seen {
+				i = len(scripts)
+				index[name] = i
+				scripts = append(scripts, Script{Name: name})
+			}
+			if desc != "" {
+				scripts[i].Description = desc
+			}
+			current = append(current, i)
+		}
+	}
+	return scripts, nil
+}
+
+// makeRule parses a rule line, "targets: prerequisites ## description",
+// returning the targets worth listing. Variable assignments, including
+// target-specific ones, are not rules.
+func makeRule(line string) (targets []string, desc string, ok bool) {
+	text, desc, _ := strings.Cut(line, "##")
+	desc = strings.TrimSpace(desc)
+	if i := strings.Index(text, "#"); i >= 0 {
+		text = text[:i]
+	}
+	colon := strings.IndexByte(text, ':')
+	if colon < 0 || strings.ContainsRune(text[:colon], '=') {
+		return nil, "", false
+	}
+	rest := strings.TrimLeft(text[colon+1:], ":")
+	if strings.HasPrefix(rest, "=") || strings.ContainsRune(rest, '=') {
+		return nil, "", false
+	}
+	for _, name := range strings.Fields(text[:colon]) {
+		if isListedTarget(name) {
+			targets = append(targets, name)
+		}
+	}
+	return targets, desc, len(targets) > 0
+}
+
+// isListedTarget reports whether a target looks like a task rather than a
+// file or a special target.
+func isListedTarget(name string) bool {
+	return !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, "%$/.()")
+}
+
+// joinCommand appends a recipe line to a command shown in the menu.
+func joinCommand(command, line string) string {
+	if command == "" {
+		return line
+	}
+	return command + "; " + line
+}
@@ -4,33 +4,87 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 )
 
-// Script represents a single script entry from package.json.
+// Script represents a single script entry from package.json, or a task or
+// target from another source.
 type Script struct {
-	Name        string // e.g. "test", "build", "dev"
-	Command     string // e.g. "vitest run", "next build"
-	Description string // from x-skit or empty
-	Group       string // prefix before ":" (e.g. "test" for "test:watch")
+	Name         string   // e.g. "test", "build", "dev"
+	Command      string   // e.g. "vitest run", "next build"
+	Description  string   // from x-skit, a doc comment or a task description, or empty
+	Group        string   // prefix before ":" (e.g. "test" for "test:watch")
+	Workspace    string   // package name, set when listing scripts of several packages
+	PkgPath      string   // package.json the script belongs to, set along with Workspace
+	Dependencies []string // tasks run before this one, for Deno and Taskfile
+	Source       string   // "npm", "deno", "composer", "poe", "pdm", "hatch", "make", "just" or "task"
+	Pre          string   // command of the "pre<name>" hook run before this script, if any
+	Post         string   // command of the "post<name>" hook run after this script, if any
+	HookOf       string   // script this one is the pre or post hook of, if any
+
+	// Set from the object form of x-skit.
+	Tags    []string          // labels matched by the menu filter
+	Hidden  bool              // left out of the menu unless filtered for
+	Confirm bool              // ask before running
+	Env     map[string]string // environment variables set for the run
+	Args    []string          // arguments passed before the forwarded ones
+	Cwd     string            // directory the runner starts in, relative to the package.json
+	Aliases []string          // other names the script runs by
+}
+
+// HasName reports whether the script is called name, or has it as an alias.
+func (s Script) HasName(name string) bool {
+	return s.Name == name || slices.Contains(s.Aliases, name)
+}
+
+// Lookup returns the index of the script called name, or else of the first
+// one with that alias, or -1.
+func Lookup(scripts []Script, name string) int {
+	if i := slices.IndexFunc(scripts, func(s Script) bool { return s.Name == name }); i >= 0 {
+		return i
+	}
+	return slices.IndexFunc(scripts, func(s Script) bool { return s.HasName(name) })
 }
 
 // WorkspaceInfo represents a sub-project in a monorepo.
 type WorkspaceInfo struct {
-	Name    string // package name from package.json (e.g. "@acme/web")
-	Path    string // relative directory path (e.g. "apps/web")
-	PkgPath string // absolute path to the package.json
+	Name         string   // package name from package.json (e.g. "@acme/web")
+	Path         string   // relative directory path (e.g. "apps/web")
+	PkgPath      string   // absolute path to the package.json
+	Dependencies []string // names from dependencies, devDependencies, peer and optional dependencies, sorted
 }
 
 // packageJSON is the minimal structure we need from package.json.
 type packageJSON struct {
 	Name       string            `json:"name"`
 	Scripts    map[string]string `json:"scripts"`
-	XSkit      map[string]string `json:"x-skit"`
+	XSkit      map[string]xSkit  `json:"x-skit"`
 	Workspaces workspacesField   `json:"-"`
 }
 
+// xSkit is the x-skit entry of a script: a description, or an object with a
+// description and settings shaping the menu and the run.
+type xSkit struct {
+	Description string            `json:"description"`
+	Tags        []string          `json:"tags"`
+	Hidden      bool              `json:"hidden"`
+	Confirm     bool              `json:"confirm"`
+	Env         map[string]string `json:"env"`
+	Args        []string          `json:"args"`
+	Cwd         string            `json:"cwd"`
+	Aliases     []string          `json:"aliases"`
+}
+
+func (x *xSkit) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &x.Description); err == nil {
+		return nil
+	}
+	type object xSkit
+	return json.Unmarshal(data, (*object)(x))
+}
+
 // workspacesField handles both "workspaces": ["a/*"] and "workspaces": {"packages": ["a/*"]}.
 type workspacesField []string
 
@@ -56,12 +110,55 @@ func (w *workspacesField) UnmarshalJSON(data []byte) error {
 type fullPackageJSON struct {
 	Name       string            `json:"name"`
 	Scripts    map[string]string `json:"scripts"`
-	XSkit      map[string]string `json:"x-skit"`
+	XSkit      map[string]xSkit  `json:"x-skit"`
 	Workspaces workspacesField   `json:"workspaces"`
 }
 
-// Parse reads a package.json file and returns its scripts sorted by group then name.
+// Parse reads the scripts of a project file — a package.json, a deno.json
+// or deno.jsonc, a composer.json, a pyproject.toml, a Makefile, a justfile
+// or a Taskfile — along with those of the composer.json, pyproject.toml,
+// Makefile, justfile and Taskfile next to it, each tagged with its source.
+// Scripts are sorted by group then name. A companion file that cannot be
+// read is skipped.
 func Parse(path string) ([]Script, error) {
+	primary := sourceOf(path)
+	scripts, err := primary.parse(path)
+	if err != nil {
+		return nil, err
+	}
+	setSource(scripts, primary.name)
+
+	dir := filepath.Dir(path)
+	for _, src := range sources {
+		if !src.companion || src.name == primary.name {
+			continue
+		}
+		if p := src.find(dir); p != "" {
+			if more, err := src.parse(p); err == nil {
+				setSource(more, src.name)
+				scripts = append(scripts, more...)
+			}
+		}
+	}
+
+	if len(scripts) == 0 {
+		return nil, nil
+	}
+	groupScripts(scripts)
+	return scripts, nil
+}
+
+func setSource(scripts []Script, name string) {
+	for i := range scripts {
+		if scripts[i].Source == "" {
+			scripts[i].Source = name
+		}
+	}
+}
+
+// parsePackageJSON reads the scripts of a package.json, with their
+// descriptions and settings from the x-skit field.
+func parsePackageJSON(path string) ([]Script, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -72,42 +169,80 @@ func Parse(path string) ([]Script, error) {
 		return nil, err
 	}
 
-	if len(pkg.Scripts) == 0 {
-		return nil, nil
+	scripts := make([]Script, 0, len(pkg.Scripts))
+	for name, cmd := range pkg.Scripts {
+		x := pkg.XSkit[name]
+		scripts = append(scripts, Script{
+			Name:        name,
+			Command:     cmd,
+			Description: x.Description,
+			Tags:        x.Tags,
+			Hidden:      x.Hidden,
+			Confirm:     x.Confirm,
+			Env:         x.Env,
+			Args:        x.Args,
+			Cwd:         x.Cwd,
+			Aliases:     x.Aliases,
+		})
 	}
+	return scripts, nil
+}
 
-	// First pass: collect all group prefixes from scripts with ":"
-	groups := make(map[string]bool)
-	for name := range pkg.Scripts {
-		if idx := strings.IndexByte(name, ':'); idx > 0 {
-			groups[name[:idx]] = true
+// AttachHooks links the pre and post hooks of package.json scripts to the
+// script they run around: "prebuild" and "postbuild" to "build". A "pre" or
+// "post" script without a matching script is a script of its own. Only call
+// it for runners that run the hooks along with the script.
+func AttachHooks(scripts []Script) {
+	index := make(map[string]int, len(scripts))
+	for i, s := range scripts {
+		if s.Source == "npm" {
+			index[s.Name] = i
+		}
+	}
+	for i, s := range scripts {
+		if s.Source != "npm" {
+			continue
+		}
+		for _, prefix := range []string{"pre", "post"} {
+			name, ok := strings.CutPrefix(s.Name, prefix)
+			if !ok {
+				continue
+			}
+			main, ok := index[name]
+			if !ok {
+				continue
+			}
+			scripts[i].HookOf = name
+			if prefix == "pre" {
+				scripts[main].Pre = s.Command
+			} else {
+				scripts[main].Post = s.Command
+			}
 		}
 	}
+}
 
-	scripts := make([]Script, 0, len(pkg.Scripts))
-	for name, cmd := range pkg.Scripts {
-		s := Script{
-			Name:    name,
-			Command: cmd,
+// groupScripts sets the group of each script and sorts them: ungrouped
+// first, then by group, then by name within each group.
+func groupScripts(scripts []Script) {
+	// First pass: collect all group prefixes from scripts with ":"
+	groups := make(map[string]bool)
+	for _, s := range scripts {
+		if idx := strings.IndexByte(s.Name, ':'); idx > 0 {
+			groups[s.Name[:idx]] = true
 		}
+	}
 
+	for i, s := range scripts {
 		// Extract group: either from ":" prefix or by matching an existing group
-		if idx := strings.IndexByte(name, ':'); idx > 0 {
-			s.Group = name[:idx]
-		} else if groups[name] {
+		if idx := strings.IndexByte(s.Name, ':'); idx > 0 {
+			scripts[i].Group = s.Name[:idx]
+		} else if groups[s.Name] {
 			// Script name matches a group prefix (e.g. "test" when "test:watch" exists)
-			s.Group = name
-		}
-
-		// Description from x-skit field
-		if desc, ok := pkg.XSkit[name]; ok {
-			s.Description = desc
+			scripts[i].Group = s.Name
 		}
-
-		scripts = append(scripts, s)
 	}
 
-	// Sort: ungrouped first, then by group, then by name within each group
 	sort.Slice(scripts, func(i, j int) bool {
 		gi, gj := scripts[i].Group, scripts[j].Group
 		if gi == "" && gj != "" {
@@ -119,15 +254,50 @@ func Parse(path string) ([]Script, error) {
 		if gi != gj {
 			return gi < gj
 		}
-		return scripts[i].Name < scripts[j].Name
+		if scripts[i].Name != scripts[j].Name {
+			return scripts[i].Name < scripts[j].Name
+		}
+		return sourceIndex(scripts[i].Source) < sourceIndex(scripts[j].Source)
 	})
+}
 
-	return scripts, nil
+// dependencyFields are the package.json fields naming other packages.
+type dependencyFields struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
 }
 
-// ParseName reads just the "name" field from a package.json.
-func ParseName(path string) string {
+// ParseDependencies reads the names of every package a package.json depends
+// on, from all of its dependency fields, sorted and without duplicates.
+func ParseDependencies(path string) []string {
 	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var pkg dependencyFields
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies, pkg.PeerDependencies, pkg.OptionalDependencies} {
+		for name := range deps {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseName reads just the "name" field from a package.json or deno.json.
+func ParseName(path string) string {
+	data, err := readJSONC(path)
 	if err != nil {
 		return ""
 	}
@@ -157,6 +327,66 @@ func FindPackageJSON(dir string) string {
 	return ""
 }
 
+// FindProject searches for the nearest project file starting from dir and
+// walking up parent directories: a package.json, a deno.json or deno.jsonc
+// with tasks, or else a composer.json, pyproject.toml, Makefile, justfile or
+// Taskfile. In a directory with both a package.json and a Deno config, the
+// package.json wins unless it has no scripts and the Deno config has tasks.
+func FindProject(dir string) string {
+	for {
+		if path := projectIn(dir); path != "" {
+			return path
+		}
+
+		parent := dir[:strings.LastIndex(dir, "/")]
+		if parent == dir || parent == "" {
+			break
+		}
+		dir = parent
+	}
+	return ""
+}
+
+// projectIn returns the project file of a directory, or "" if it has none.
+func projectIn(dir string) string {
+	pkgPath := dir + "/package.json"
+	_, err := os.Stat(pkgPath)
+	hasPkg := err == nil
+	for _, name := range denoConfigNames {
+		denoPath := dir + "/" + name
+		if _, err := os.Stat(denoPath); err != nil {
+			continue
+		}
+		if !hasDenoTasks(denoPath) {
+			break
+		}
+		if hasPkg {
+			if scripts, err := parsePackageJSON(pkgPath); err != nil || len(scripts) > 0 {
+				return pkgPath
+			}
+		}
+		return denoPath
+	}
+	if hasPkg {
+		return pkgPath
+	}
+	for _, src := range sources {
+		if !src.companion {
+			continue
+		}
+		path := src.find(dir)
+		if path == "" {
+			continue
+		}
+		// A pyproject.toml is a project file only for the tasks it defines.
+		if src.name == "" && SourceName(path) == "" {
+			continue
+		}
+		return path
+	}
+	return ""
+}
+
 // FindRootPackageJSON walks up from dir to find the topmost package.json.
 func FindRootPackageJSON(dir string) string {
 	var root string
@@ -177,6 +407,7 @@ func FindRootPackageJSON(dir string) string {
 
 // ParseWorkspaces reads workspace patterns from a root package.json (npm/yarn/bun)
 // or from pnpm-workspace.yaml, then resolves all sub-project package.json files.
+// Patterns may use "**" and "!" exclusions, see matchWorkspaceDirs.
 func ParseWorkspaces(rootPkgPath string) []WorkspaceInfo {
 	rootDir := filepath.Dir(rootPkgPath)
 	patterns := readWorkspacePatterns(rootPkgPath, rootDir)
@@ -185,42 +416,25 @@ func ParseWorkspaces(rootPkgPath string) []WorkspaceInfo {
 	}
 
 	var workspaces []WorkspaceInfo
-	seen := make(map[string]bool)
-
-	for _, pattern := range patterns {
-		// Resolve the glob pattern relative to root
-		absPattern := filepath.Join(rootDir, pattern)
-
-		matches, err := filepath.Glob(absPattern)
-		if err != nil {
+	for _, rel := range matchWorkspaceDirs(rootDir, patterns) {
+		dir := filepath.Join(rootDir, filepath.FromSlash(rel))
+		pkgPath := filepath.Join(dir, "package.json")
+		if _, err := os.Stat(pkgPath); err != nil {
 			continue
 		}
 
-		for _, match := range matches {
-			pkgPath := filepath.Join(match, "package.json")
-			if _, err := os.Stat(pkgPath); err != nil {
-				continue
-			}
-
-			// Deduplicate
-			abs, _ := filepath.Abs(pkgPath)
-			if seen[abs] {
-				continue
-			}
-			seen[abs] = true
-
-			relPath, _ := filepath.Rel(rootDir, match)
-			name := ParseName(pkgPath)
-			if name == "" {
-				name = relPath
-			}
-
-			workspaces = append(workspaces, WorkspaceInfo{
-				Name:    name,
-				Path:    relPath,
-				PkgPath: pkgPath,
-			})
+		relPath := filepath.FromSlash(rel)
+		name := ParseName(pkgPath)
+		if name == "" {
+			name = relPath
 		}
+
+		workspaces = append(workspaces, WorkspaceInfo{
+			Name:         name,
+			Path:         relPath,
+			PkgPath:      pkgPath,
+			Dependencies: ParseDependencies(pkgPath),
+		})
 	}
 
 	sort.Slice(workspaces, func(i, j int) bool {
@@ -242,26 +456,9 @@ func readWorkspacePatterns(pkgPath, rootDir string) []string {
 	}
 
 	// Try pnpm-workspace.yaml
-	pnpmPath := filepath.Join(rootDir, "pnpm-workspace.yaml")
-	pnpmData, err := os.ReadFile(pnpmPath)
+	ws, err := ReadPnpmWorkspace(rootDir)
 	if err != nil {
 		return nil
 	}
-	return parsePnpmWorkspaceYAML(string(pnpmData))
-}
-
-// parsePnpmWorkspaceYAML does a simple line-based parse of pnpm-workspace.yaml.
-func parsePnpmWorkspaceYAML(content string) []string {
-	var patterns []string
-	for _, line := range strings.Split(content, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "- ") {
-			pattern := strings.TrimPrefix(line, "- ")
-			pattern = strings.Trim(pattern, "'\"")
-			if pattern != "" {
-				patterns = append(patterns, pattern)
-			}
-		}
-	}
-	return patterns
+	return ws.Packages
 }
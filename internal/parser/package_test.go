@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -106,6 +107,125 @@ func TestParseWithXSkit(t *testing.T) {
 	}
 }
 
+func TestParseXSkitObject(t *testing.T) {
+	dir := t.TempDir()
+	pkg := filepath.Join(dir, "package.json")
+	content := `{
+  "scripts": {
+    "dev": "vite",
+    "deploy": "./deploy.sh",
+    "seed": "node seed.js"
+  },
+  "x-skit": {
+    "dev": "Start the dev server",
+    "deploy": {
+      "description": "Deploy to production",
+      "tags": ["ops", "prod"],
+      "confirm": true,
+      "env": {"NODE_ENV": "production"},
+      "args": ["--region", "eu"],
+      "cwd": "infra",
+      "aliases": ["ship"]
+    },
+    "seed": {"hidden": true}
+  }
+}`
+	if err := os.WriteFile(pkg, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := Parse(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev := scripts[Lookup(scripts, "dev")]
+	if dev.Description != "Start the dev server" {
+		t.Errorf("dev description = %q", dev.Description)
+	}
+	deploy := scripts[Lookup(scripts, "ship")]
+	if deploy.Name != "deploy" || deploy.Description != "Deploy to production" || !deploy.Confirm || deploy.Cwd != "infra" {
+		t.Errorf("deploy = %+v", deploy)
+	}
+	if strings.Join(deploy.Tags, ",") != "ops,prod" || strings.Join(deploy.Args, " ") != "--region eu" {
+		t.Errorf("deploy tags = %v, args = %v", deploy.Tags, deploy.Args)
+	}
+	if deploy.Env["NODE_ENV"] != "production" {
+		t.Errorf("deploy env = %v", deploy.Env)
+	}
+	if seed := scripts[Lookup(scripts, "seed")]; !seed.Hidden || seed.Description != "" {
+		t.Errorf("seed = %+v", seed)
+	}
+	if i := Lookup(scripts, "missing"); i != -1 {
+		t.Errorf("Lookup(missing) = %d, want -1", i)
+	}
+}
+
+func TestParseKeepsXSkitCwdOutsidePackage(t *testing.T) {
+	for _, cwd := range []string{"../..", "/tmp", "infra/../../x"} {
+		dir := t.TempDir()
+		pkg := filepath.Join(dir, "package.json")
+		content := `{"scripts": {"deploy": "./deploy.sh"}, "x-skit": {"deploy": {"cwd": "` + cwd + `"}}}`
+		if err := os.WriteFile(pkg, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		scripts, err := Parse(pkg)
+		if err != nil {
+			t.Fatalf("Parse with cwd %q: %v", cwd, err)
+		}
+		if len(scripts) != 1 || scripts[0].Cwd != cwd {
+			t.Errorf("scripts with cwd %q = %+v", cwd, scripts)
+		}
+	}
+}
+
+func TestAttachHooks(t *testing.T) {
+	dir := t.TempDir()
+	pkg := filepath.Join(dir, "package.json")
+	content := `{
+  "scripts": {
+    "prebuild": "rimraf dist",
+    "build": "vite build",
+    "postbuild": "size-limit",
+    "pretest": "eslint .",
+    "prepare": "husky",
+    "postinstall": "patch-package"
+  }
+}`
+	if err := os.WriteFile(pkg, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scripts, err := Parse(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i := Lookup(scripts, "prebuild"); scripts[i].HookOf != "" {
+		t.Errorf("Parse linked prebuild to %q, want hooks left to AttachHooks", scripts[i].HookOf)
+	}
+	AttachHooks(scripts)
+	got := make(map[string]Script)
+	for _, s := range scripts {
+		got[s.Name] = s
+	}
+
+	if b := got["build"]; b.Pre != "rimraf dist" || b.Post != "size-limit" {
+		t.Errorf("build hooks = %q / %q, want rimraf dist / size-limit", b.Pre, b.Post)
+	}
+	if h := got["prebuild"].HookOf; h != "build" {
+		t.Errorf("prebuild.HookOf = %q, want build", h)
+	}
+	if h := got["postbuild"].HookOf; h != "build" {
+		t.Errorf("postbuild.HookOf = %q, want build", h)
+	}
+	// Hooks of scripts that do not exist are scripts of their own.
+	for _, name := range []string{"pretest", "prepare", "postinstall"} {
+		if h := got[name].HookOf; h != "" {
+			t.Errorf("%s.HookOf = %q, want none", name, h)
+		}
+	}
+}
+
 func TestParseNoScripts(t *testing.T) {
 	dir := t.TempDir()
 	pkg := filepath.Join(dir, "package.json")
@@ -162,3 +282,35 @@ func TestFindPackageJSONNotFound(t *testing.T) {
 		t.Errorf("FindPackageJSON(%q) = %q, want empty", dir, found)
 	}
 }
+
+func TestParseWorkspacesDependencies(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("package.json", `{"workspaces": ["packages/*"]}`)
+	write("packages/ui/package.json", `{"name": "@acme/ui"}`)
+	write("packages/web/package.json", `{
+  "name": "@acme/web",
+  "dependencies": {"@acme/ui": "workspace:*", "react": "^19.0.0"},
+  "devDependencies": {"typescript": "^5.0.0", "react": "^19.0.0"}
+}`)
+
+	workspaces := ParseWorkspaces(filepath.Join(dir, "package.json"))
+	if len(workspaces) != 2 {
+		t.Fatalf("expected 2 workspaces, got %d", len(workspaces))
+	}
+	if len(workspaces[0].Dependencies) != 0 {
+		t.Errorf("@acme/ui dependencies = %v, want none", workspaces[0].Dependencies)
+	}
+	want := []string{"@acme/ui", "react", "typescript"}
+	if got := workspaces[1].Dependencies; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("@acme/web dependencies = %v, want %v", got, want)
+	}
+}
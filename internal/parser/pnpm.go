@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PnpmWorkspace holds the settings of pnpm-workspace.yaml that skit uses.
+type PnpmWorkspace struct {
+	Packages []string                     // workspace globs
+	Catalog  map[string]string            // default catalog: dependency → version range
+	Catalogs map[string]map[string]string // named catalogs
+}
+
+// ReadPnpmWorkspace reads rootDir/pnpm-workspace.yaml.
+func ReadPnpmWorkspace(rootDir string) (PnpmWorkspace, error) {
+	data, err := os.ReadFile(filepath.Join(rootDir, "pnpm-workspace.yaml"))
+	if err != nil {
+		return PnpmWorkspace{}, err
+	}
+	return ParsePnpmWorkspace(string(data))
+}
+
+// ParsePnpmWorkspace parses the content of pnpm-workspace.yaml. Only the
+// top-level packages, catalog and catalogs keys are read; other settings
+// are ignored.
+func ParsePnpmWorkspace(content string) (PnpmWorkspace, error) {
+	var ws PnpmWorkspace
+	doc, err := parseYAML(content)
+	if err != nil {
+		return ws, fmt.Errorf("pnpm-workspace.yaml: %w", err)
+	}
+	if doc == nil {
+		return ws, nil
+	}
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return ws, fmt.Errorf("pnpm-workspace.yaml: expected a mapping")
+	}
+
+	switch packages := root["packages"].(type) {
+	case []any:
+		for _, p := range packages {
+			if s, ok := p.(string); ok && s != "" {
+				ws.Packages = append(ws.Packages, s)
+			}
+		}
+	case string:
+		ws.Packages = []string{packages}
+	}
+	ws.Catalog = stringMap(root["catalog"])
+	if catalogs, ok := root["catalogs"].(map[string]any); ok {
+		ws.Catalogs = make(map[string]map[string]string, len(catalogs))
+		for name, c := range catalogs {
+			ws.Catalogs[name] = stringMap(c)
+		}
+	}
+	return ws, nil
+}
+
+// stringMap returns the string values of a YAML mapping.
+func stringMap(v any) map[string]string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, val := range m {
+		if s, ok := val.(string); ok {
+			out[k] = s
+		}
+	}
+	return out
+}
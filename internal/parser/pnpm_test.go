@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestParsePnpmWorkspacePackages(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name: "block sequence",
+			content: `packages:
+  - 'packages/*'
+  - "apps/*"   # the apps
+  - tools/cli
+`,
+			want: []string{"packages/*", "apps/*", "tools/cli"},
+		},
+		{
+			name:    "flow sequence",
+			content: `packages: ["packages/*", 'apps/*', tools/cli] # all`,
+			want:    []string{"packages/*", "apps/*", "tools/cli"},
+		},
+		{
+			name: "flow sequence over several lines",
+			content: `packages: [
+  "packages/*",
+  "!**/test/**",
+]
+`,
+			want: []string{"packages/*", "!**/test/**"},
+		},
+		{
+			name: "sequence at the key indentation",
+			content: `# pnpm settings
+packages:
+- packages/*
+- '!packages/legacy'
+`,
+			want: []string{"packages/*", "!packages/legacy"},
+		},
+		{
+			name: "other lists are ignored",
+			content: `onlyBuiltDependencies:
+  - esbuild
+packages:
+  - packages/*
+catalog:
+  react: ^18.2.0
+overrides:
+  - name: lodash
+    version: 4.17.21
+`,
+			want: []string{"packages/*"},
+		},
+		{
+			name:    "no packages key",
+			content: "catalog:\n  react: ^18.2.0\n",
+		},
+		{
+			name: "empty file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, err := ParsePnpmWorkspace(tt.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(ws.Packages, tt.want) {
+				t.Errorf("Packages = %q, want %q", ws.Packages, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePnpmWorkspaceCatalogs(t *testing.T) {
+	ws, err := ParsePnpmWorkspace(`packages:
+  - packages/*
+
+catalog:
+  react: ^18.2.0
+  "@types/node": '20.x'   # pinned
+
+catalogs:
+  react17:
+    react: ^17.0.2
+    react-dom: ^17.0.2
+  legacy: {lodash: 3.10.1, "left-pad": ~1.0.0}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"react": "^18.2.0", "@types/node": "20.x"}; !maps.Equal(ws.Catalog, want) {
+		t.Errorf("Catalog = %v, want %v", ws.Catalog, want)
+	}
+	if want := map[string]string{"react": "^17.0.2", "react-dom": "^17.0.2"}; !maps.Equal(ws.Catalogs["react17"], want) {
+		t.Errorf("Catalogs[react17] = %v, want %v", ws.Catalogs["react17"], want)
+	}
+	if want := map[string]string{"lodash": "3.10.1", "left-pad": "~1.0.0"}; !maps.Equal(ws.Catalogs["legacy"], want) {
+		t.Errorf("Catalogs[legacy] = %v, want %v", ws.Catalogs["legacy"], want)
+	}
+}
+
+func TestParsePnpmWorkspaceErrors(t *testing.T) {
+	for _, content := range []string{
+		"packages: [packages/*",
+		"packages:\n  - a\n    - b\n",
+		"- a\n- b\n",
+	} {
+		if _, err := ParsePnpmWorkspace(content); err == nil {
+			t.Errorf("ParsePnpmWorkspace(%q) succeeded, want an error", content)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parsePyproject reads the tasks of a pyproject.toml for poe
+// ([tool.poe.tasks]), pdm ([tool.pdm.scripts]) and hatch
+// ([tool.hatch.envs.<env>.scripts]), each script tagged with its tool.
+// Hatch scripts outside the default environment are named "env:script", as
+// hatch run expects.
+func parsePyproject(path string) ([]Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseTOML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	tool, _ := doc["tool"].(map[string]any)
+
+	var scripts []Script
+	poe, _ := tool["poe"].(map[string]any)
+	for name, v := range tomlMap(poe["tasks"]) {
+		if strings.HasPrefix(name, "_") {
+			continue // private task
+		}
+		s := pythonTask(name, v, "help", []string{"cmd", "shell", "script", "ref", "expr"}, "sequence")
+		s.Source = "poe"
+		scripts = append(scripts, s)
+	}
+
+	pdm, _ := tool["pdm"].(map[string]any)
+	for name, v := range tomlMap(pdm["scripts"]) {
+		if name == "_" {
+			continue // options shared by every script
+		}
+		s := pythonTask(name, v, "help", []string{"cmd", "shell", "call"}, "composite")
+		s.Source = "pdm"
+		scripts = append(scripts, s)
+	}
+
+	hatch, _ := tool["hatch"].(map[string]any)
+	for env, v := range tomlMap(hatch["envs"]) {
+		envTable, _ := v.(map[string]any)
+		for name, cmd := range tomlMap(envTable["scripts"]) {
+			if env != "default" {
+				name = env + ":" + name
+			}
+			s := pythonTask(name, cmd, "", nil, "")
+			s.Source = "hatch"
+			scripts = append(scripts, s)
+		}
+	}
+	return scripts, nil
+}
+
+func tomlMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// pythonTask reads a task that is a command, a list of commands, or a table
+// with a description key, command keys and a key listing tasks to run in
+// sequence.
+func pythonTask(name string, v any, descKey string, cmdKeys []string, seqKey string) Script {
+	s := Script{Name: name}
+	switch v := v.(type) {
+	case string:
+		s.Command = v
+	case []any:
+		s.Command = joinStrings(v, "; ")
+	case map[string]any:
+		if descKey != "" {
+			s.Description, _ = v[descKey].(string)
+		}
+		for _, k := range cmdKeys {
+			switch cmd := v[k].(type) {
+			case string:
+				s.Command = cmd
+			case []any:
+				s.Command = joinStrings(cmd, " ")
+			}
+			if s.Command != "" {
+				break
+			}
+		}
+		if seq, ok := v[seqKey].([]any); ok && s.Command == "" {
+			s.Command = joinStrings(seq, "; ")
+		}
+		if deps, ok := v["deps"].([]any); ok {
+			for _, d := range deps {
+				if dep, ok := d.(string); ok {
+					s.Dependencies = append(s.Dependencies, dep)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// joinStrings joins the strings of a list, and the commands of inline
+// tables such as poe's sequence steps.
+func joinStrings(items []any, sep string) string {
+	var parts []string
+	for _, it := range items {
+		switch it := it.(type) {
+		case string:
+			parts = append(parts, it)
+		case map[string]any:
+			for _, k := range []string{"cmd", "shell", "script", "ref", "call"} {
+				if cmd, ok := it[k].(string); ok {
+					parts = append(parts, cmd)
+					break
+				}
+			}
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// source is a kind of file scripts are read from.
+type source struct {
+	// name is the Script.Source of its scripts, empty when they set it
+	// themselves, for files read by several tools.
+	name  string
+	files []string // file names, by precedence
+	parse func(path string) ([]Script, error)
+	// companion sources are listed alongside the scripts of the project
+	// file found in the same directory.
+	companion bool
+}
+
+// sources lists the script sources. A script name defined by several of them
+// resolves to the first.
+var sources = []source{
+	{name: "npm", files: []string{"package.json"}, parse: parsePackageJSON},
+	{name: "deno", files: denoConfigNames, parse: parseDeno},
+	{name: "composer", files: []string{"composer.json"}, parse: parseComposer, companion: true},
+	{name: "", files: []string{"pyproject.toml"}, parse: parsePyproject, companion: true},
+	{name: "make", files: []string{"GNUmakefile", "makefile", "Makefile"}, parse: parseMakefile, companion: true},
+	{name: "just", files: []string{"justfile", "Justfile", ".justfile"}, parse: parseJustfile, companion: true},
+	{name: "task", files: []string{
+		"Taskfile.yml", "taskfile.yml", "Taskfile.yaml", "taskfile.yaml",
+		"Taskfile.dist.yml", "taskfile.dist.yml", "Taskfile.dist.yaml", "taskfile.dist.yaml",
+	}, parse: parseTaskfile, companion: true},
+}
+
+// sourceOf returns the source a file belongs to by its name, package.json
+// by default.
+func sourceOf(path string) source {
+	base := filepath.Base(path)
+	for _, src := range sources {
+		for _, name := range src.files {
+			if base == name {
+				return src
+			}
+		}
+	}
+	return sources[0]
+}
+
+// SourceName returns the source of a project file's own scripts: "npm",
+// "deno", "composer", "make", "just" or "task", or for a pyproject.toml the
+// tool of its first tasks, "poe", "pdm" or "hatch".
+func SourceName(path string) string {
+	src := sourceOf(path)
+	if src.name != "" {
+		return src.name
+	}
+	scripts, _ := src.parse(path)
+	for _, name := range pythonTools {
+		for _, s := range scripts {
+			if s.Source == name {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// pythonTools are the tools reading pyproject.toml, by precedence.
+var pythonTools = []string{"poe", "pdm", "hatch"}
+
+// find returns the path of the source's file in dir, or "" if there is none.
+func (src source) find(dir string) string {
+	for _, name := range src.files {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// sourceIndex returns the position of a script source in sources, for
+// sorting.
+func sourceIndex(name string) int {
+	for i, src := range sources {
+		if src.name == name {
+			return i
+		}
+		if src.name == "" && slices.Contains(pythonTools, name) {
+			return i
+		}
+	}
+	return len(sources)
+}
@@ -0,0 +1,356 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeSources creates files in a temporary directory and returns it.
+func writeSources(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// byName indexes scripts by name, failing on duplicates.
+func byName(t *testing.T, scripts []Script) map[string]Script {
+	t.Helper()
+	m := make(map[string]Script, len(scripts))
+	for _, s := range scripts {
+		if _, ok := m[s.Name]; ok {
+			t.Fatalf("duplicate script %q", s.Name)
+		}
+		m[s.Name] = s
+	}
+	return m
+}
+
+func TestParseMakefile(t *testing.T) {
+	dir := writeSources(t, map[string]string{"Makefile": `VERSION := 1.2.0
+CC = gcc
+export PATH := bin:$(PATH)
+
+.PHONY: build test lint clean
+
+## Build the binary
+build: deps ## Build it for the current platform
+	@echo building $(VERSION)
+	go build ./...
+
+## Run the tests
+## with the race detector
+test:
+	go test -race ./...
+
+lint fmt: ## Lint and format
+	golangci-lint run
+
+clean:
+	-rm -rf dist
+
+deps:
+	go mod download
+
+build: VERSION := dev
+
+%.o: %.c
+	$(CC) -c $<
+
+dist/app: build
+	cp app dist/app
+
+define HELP
+usage: make target
+endef
+`})
+
+	scripts, err := parseMakefile(filepath.Join(dir, "Makefile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := byName(t, scripts)
+
+	var names []string
+	for _, s := range scripts {
+		names = append(names, s.Name)
+	}
+	if want := []string{"build", "test", "lint", "fmt", "clean", "deps"}; !slices.Equal(names, want) {
+		t.Errorf("targets = %v, want %v", names, want)
+	}
+	if d := got["build"].Description; d != "Build it for the current platform" {
+		t.Errorf("build description = %q", d)
+	}
+	if c := got["build"].Command; c != "echo building $(VERSION); go build ./..." {
+		t.Errorf("build command = %q", c)
+	}
+	if d := got["test"].Description; d != "Run the tests with the race detector" {
+		t.Errorf("test description = %q", d)
+	}
+	if d := got["fmt"].Description; d != "Lint and format" {
+		t.Errorf("fmt description = %q", d)
+	}
+	if c := got["clean"]; c.Description != "" || c.Command != "rm -rf dist" {
+		t.Errorf("clean = %+v", c)
+	}
+}
+
+func TestParseJustfile(t *testing.T) {
+	dir := writeSources(t, map[string]string{"justfile": `set dotenv-load
+alias b := build
+version := "1.2.0"
+
+# Show the recipes
+default:
+    @just --list
+
+# Build the project
+build target="debug": clean (gen "x") && notify
+    cargo build --profile {{target}}
+
+[doc('Run the tests')]
+test *args:
+    cargo test {{args}}
+
+[private]
+notify:
+    echo done
+
+_gen name:
+    echo {{name}}
+
+clean:
+    cargo clean
+`})
+
+	scripts, err := parseJustfile(filepath.Join(dir, "justfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := byName(t, scripts)
+
+	if len(scripts) != 4 {
+		t.Errorf("recipes = %v, want default, build, test and clean", scripts)
+	}
+	if d := got["default"].Description; d != "Show the recipes" {
+		t.Errorf("default description = %q", d)
+	}
+	build := got["build"]
+	if build.Description != "Build the project" || build.Command != "cargo build --profile {{target}}" {
+		t.Errorf("build = %+v", build)
+	}
+	if !slices.Equal(build.Dependencies, []string{"clean"}) {
+		t.Errorf("build dependencies = %v, want [clean]", build.Dependencies)
+	}
+	if d := got["test"].Description; d != "Run the tests" {
+		t.Errorf("test description = %q", d)
+	}
+	if _, ok := got["notify"]; ok {
+		t.Error("private recipe listed")
+	}
+}
+
+func TestParseJustfileHeaderWithTrailingSpace(t *testing.T) {
+	dir := writeSources(t, map[string]string{"justfile": "# Build it\nbuild: \t\n    cargo build\n\ntest:  \n\tcargo test\n"})
+
+	scripts, err := parseJustfile(filepath.Join(dir, "justfile"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := byName(t, scripts)
+
+	if build := got["build"]; build.Description != "Build it" || build.Command != "cargo build" {
+		t.Errorf("build = %+v", build)
+	}
+	if test := got["test"]; test.Command != "cargo test" {
+		t.Errorf("test = %+v", test)
+	}
+}
+
+func TestParseTaskfile(t *testing.T) {
+	dir := writeSources(t, map[string]string{"Taskfile.yml": `version: '3'
+
+vars:
+  APP: skit
+
+tasks:
+  build:
+    desc: Build the binary
+    deps: [generate, {task: lint}]
+    cmds:
+      - go build -o {{.APP}} .
+      - task: package
+  generate: go generate ./...
+  lint:
+    cmd: golangci-lint run
+  release:
+    cmds:
+      - |
+        goreleaser release
+        --clean
+  package:
+    internal: true
+    cmds:
+      - tar czf app.tgz app
+`})
+
+	scripts, err := parseTaskfile(filepath.Join(dir, "Taskfile.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := byName(t, scripts)
+
+	if len(scripts) != 4 {
+		t.Errorf("tasks = %v, want build, generate, lint and release", scripts)
+	}
+	build := got["build"]
+	if build.Description != "Build the binary" || build.Command != "go build -o {{.APP}} .; task package" {
+		t.Errorf("build = %+v", build)
+	}
+	if !slices.Equal(build.Dependencies, []string{"generate", "lint"}) {
+		t.Errorf("build dependencies = %v, want [generate lint]", build.Dependencies)
+	}
+	if c := got["generate"].Command; c != "go generate ./..." {
+		t.Errorf("generate command = %q", c)
+	}
+	if c := got["release"].Command; c != "goreleaser release\n--clean" {
+		t.Errorf("release command = %q", c)
+	}
+}
+
+func TestParseListsCompanionSources(t *testing.T) {
+	dir := writeSources(t, map[string]string{
+		"package.json": `{"scripts": {"build": "vite build", "dev": "vite"}}`,
+		"Makefile":     "build: ## Build the image\n\tdocker build .\ndeploy:\n\t./deploy.sh\n",
+		"justfile":     "# Seed the database\nseed:\n    ./seed.sh\n",
+		"Taskfile.yml": "version: '3'\ntasks:\n  lint: eslint .\n",
+	})
+
+	scripts, err := Parse(filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range scripts {
+		got = append(got, s.Source+":"+s.Name)
+	}
+	want := []string{"npm:build", "make:build", "make:deploy", "npm:dev", "task:lint", "just:seed"}
+	if !slices.Equal(got, want) {
+		t.Errorf("scripts = %v, want %v", got, want)
+	}
+
+	// A directory with only a Makefile is a project too.
+	dir = writeSources(t, map[string]string{"Makefile": "test:\n\tgo test ./...\n"})
+	sub := filepath.Join(dir, "cmd")
+	os.MkdirAll(sub, 0755)
+	if got, want := FindProject(sub), filepath.Join(dir, "Makefile"); got != want {
+		t.Errorf("FindProject = %q, want %q", got, want)
+	}
+	if got := SourceName(filepath.Join(dir, "Makefile")); got != "make" {
+		t.Errorf("SourceName = %q, want make", got)
+	}
+}
+
+func TestParseComposer(t *testing.T) {
+	dir := writeSources(t, map[string]string{"composer.json": `{
+  "name": "acme/app",
+  "scripts": {
+    "test": "phpunit",
+    "check": ["@lint", "@test"],
+    "lint": "phpcs src"
+  },
+  "scripts-descriptions": {"test": "Run the unit tests"}
+}`})
+
+	scripts, err := Parse(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := byName(t, scripts)
+	if len(got) != 3 {
+		t.Fatalf("got %d scripts, want 3", len(got))
+	}
+	if s := got["test"]; s.Command != "phpunit" || s.Description != "Run the unit tests" || s.Source != "composer" {
+		t.Errorf("test = %+v", s)
+	}
+	if c := got["check"].Command; c != "@lint; @test" {
+		t.Errorf("check command = %q", c)
+	}
+}
+
+func TestParsePyproject(t *testing.T) {
+	dir := writeSources(t, map[string]string{"pyproject.toml": `[project]
+name = "app"
+
+[tool.poe.tasks]
+test = "pytest"
+_private = "echo hidden"
+check = ["lint", "test"]
+
+[tool.poe.tasks.lint]
+cmd = "ruff check ."
+help = "Lint the code"
+deps = ["format"]
+
+[tool.pdm.scripts]
+_.env_file = ".env"
+serve = { cmd = ["flask", "run"], help = "Start the server" }
+migrate = "alembic upgrade head"
+
+[tool.hatch.envs.default.scripts]
+cov = "pytest --cov"
+
+[tool.hatch.envs.docs.scripts]
+build = ["mkdocs build", "mkdocs serve"]
+`})
+
+	path := filepath.Join(dir, "pyproject.toml")
+	scripts, err := Parse(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range scripts {
+		names = append(names, s.Source+":"+s.Name)
+	}
+	want := []string{"poe:check", "hatch:cov", "poe:lint", "pdm:migrate", "pdm:serve", "poe:test", "hatch:docs:build"}
+	if !slices.Equal(names, want) {
+		t.Errorf("scripts = %v, want %v", names, want)
+	}
+
+	got := byName(t, scripts)
+	if s := got["lint"]; s.Command != "ruff check ." || s.Description != "Lint the code" || !slices.Equal(s.Dependencies, []string{"format"}) {
+		t.Errorf("lint = %+v", s)
+	}
+	if c := got["check"].Command; c != "lint; test" {
+		t.Errorf("check command = %q", c)
+	}
+	if s := got["serve"]; s.Command != "flask run" || s.Description != "Start the server" {
+		t.Errorf("serve = %+v", s)
+	}
+	if c := got["docs:build"].Command; c != "mkdocs build; mkdocs serve" {
+		t.Errorf("docs:build command = %q", c)
+	}
+	if got := SourceName(path); got != "poe" {
+		t.Errorf("SourceName = %q, want poe", got)
+	}
+	if got := FindProject(dir); got != path {
+		t.Errorf("FindProject = %q, want %q", got, path)
+	}
+}
+
+func TestFindProjectSkipsPyprojectWithoutTasks(t *testing.T) {
+	dir := writeSources(t, map[string]string{
+		"pyproject.toml": "[project]\nname = \"app\"\n",
+		"Makefile":       "test:\n\tpytest\n",
+	})
+	if got, want := FindProject(dir), filepath.Join(dir, "Makefile"); got != want {
+		t.Errorf("FindProject = %q, want %q", got, want)
+	}
+}
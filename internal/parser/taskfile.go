@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// parseTaskfile reads the tasks of a Taskfile.yml. A task is a command, a
+// list of commands, or a mapping with desc, cmds or cmd, and deps. Internal
+// tasks are skipped.
+func parseTaskfile(path string) ([]Script, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseYAML(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	root, _ := doc.(map[string]any)
+	tasks, _ := root["tasks"].(map[string]any)
+
+	scripts := make([]Script, 0, len(tasks))
+	for name, v := range tasks {
+		s := Script{Name: name}
+		switch v := v.(type) {
+		case string:
+			s.Command = v
+		case []any:
+			s.Command = taskCommands(v)
+		case map[string]any:
+			if v["internal"] == "true" {
+				continue
+			}
+			s.Description, _ = v["desc"].(string)
+			if cmd, ok := v["cmd"].(string); ok {
+				s.Command = cmd
+			} else if cmds, ok := v["cmds"].([]any); ok {
+				s.Command = taskCommands(cmds)
+			}
+			if deps, ok := v["deps"].([]any); ok {
+				for _, d := range deps {
+					if dep := taskName(d); dep != "" {
+						s.Dependencies = append(s.Dependencies, dep)
+					}
+				}
+			}
+		}
+		scripts = append(scripts, s)
+	}
+	return scripts, nil
+}
+
+// taskCommands joins the commands of a task for display. Calls to other
+// tasks show as "task name".
+func taskCommands(cmds []any) string {
+	var command string
+	for _, c := range cmds {
+		switch c := c.(type) {
+		case string:
+			command = joinCommand(command, c)
+		case map[string]any:
+			if cmd, ok := c["cmd"].(string); ok {
+				command = joinCommand(command, cmd)
+			} else if name := taskName(c); name != "" {
+				command = joinCommand(command, "task "+name)
+			}
+		}
+	}
+	return strings.TrimSpace(command)
+}
+
+// taskName returns the task named by a dependency or a task call: a string,
+// or a mapping with a task key.
+func taskName(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case map[string]any:
+		name, _ := v["task"].(string)
+		return name
+	}
+	return ""
+}
@@ -0,0 +1,336 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseTOML parses the subset of TOML found in pyproject.toml: tables and
+// arrays of tables, dotted and quoted keys, basic, literal and multi-line
+// strings, arrays and inline tables. Tables are returned as map[string]any
+// and arrays as []any; strings keep their value and other scalars, such as
+// numbers, booleans and dates, their raw text.
+func parseTOML(content string) (map[string]any, error) {
+	p := &tomlParser{s: strings.ReplaceAll(content, "\r\n", "\n"), line: 1}
+	root := make(map[string]any)
+	current := root
+	for {
+		p.skipBlank(true)
+		if p.i >= len(p.s) {
+			return root, nil
+		}
+		var err error
+		if p.s[p.i] == '[' {
+			current, err = p.header(root)
+		} else {
+			err = p.keyValue(current)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", p.line, err)
+		}
+		p.skipBlank(false)
+		if p.i < len(p.s) && p.s[p.i] != '\n' {
+			return nil, fmt.Errorf("line %d: unexpected %q", p.line, p.rest())
+		}
+	}
+}
+
+type tomlParser struct {
+	s    string
+	i    int
+	line int
+}
+
+// rest returns the remainder of the current line, for error messages.
+func (p *tomlParser) rest() string {
+	end := strings.IndexByte(p.s[p.i:], '\n')
+	if end < 0 {
+		return p.s[p.i:]
+	}
+	return p.s[p.i : p.i+end]
+}
+
+// skipBlank skips spaces and comments, and newlines too when newlines is set.
+func (p *tomlParser) skipBlank(newlines bool) {
+	for p.i < len(p.s) {
+		switch c := p.s[p.i]; {
+		case c == ' ' || c == '\t':
+			p.i++
+		case c == '#':
+			for p.i < len(p.s) && p.s[p.i] != '\n' {
+				p.i++
+			}
+		case c == '\n' && newlines:
+			p.line++
+			p.i++
+		default:
+			return
+		}
+	}
+}
+
+// header parses a [table] or [[array of tables]] header and returns the
+// table that the following keys go into.
+func (p *tomlParser) header(root map[string]any) (map[string]any, error) {
+	array := strings.HasPrefix(p.s[p.i:], "[[")
+	if array {
+		p.i += 2
+	} else {
+		p.i++
+	}
+	path, err := p.key()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if array {
+		closing = "]]"
+	}
+	p.skipBlank(false)
+	if !strings.HasPrefix(p.s[p.i:], closing) {
+		return nil, fmt.Errorf("expected %q", closing)
+	}
+	p.i += len(closing)
+
+	if !array {
+		return tomlTable(root, path)
+	}
+	parent, err := tomlTable(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := path[len(path)-1]
+	list, _ := parent[last].([]any)
+	table := make(map[string]any)
+	parent[last] = append(list, table)
+	return table, nil
+}
+
+// keyValue parses "key = value" into table.
+func (p *tomlParser) keyValue(table map[string]any) error {
+	path, err := p.key()
+	if err != nil {
+		return err
+	}
+	p.skipBlank(false)
+	if p.i >= len(p.s) || p.s[p.i] != '=' {
+		return fmt.Errorf("expected '=' after %q", strings.Join(path, "."))
+	}
+	p.i++
+	value, err := p.value()
+	if err != nil {
+		return err
+	}
+	parent, err := tomlTable(table, path[:len(path)-1])
+	if err != nil {
+		return err
+	}
+	parent[path[len(path)-1]] = value
+	return nil
+}
+
+// tomlTable returns the table at path under root, creating missing tables.
+// A path through an array of tables leads to its last element.
+func tomlTable(root map[string]any, path []string) (map[string]any, error) {
+	table := root
+	for _, k := range path {
+		switch v := table[k].(type) {
+		case nil:
+			next := make(map[string]any)
+			table[k] = next
+			table = next
+		case map[string]any:
+			table = v
+		case []any:
+			last, ok := v[len(v)-1].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%q is not a table", k)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("%q is not a table", k)
+		}
+	}
+	return table, nil
+}
+
+// key parses a dotted key such as tool.poe."my task".
+func (p *tomlParser) key() ([]string, error) {
+	var path []string
+	for {
+		p.skipBlank(false)
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("expected a key")
+		}
+		switch c := p.s[p.i]; c {
+		case '"', '\'':
+			k, err := p.str(c)
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, k)
+		default:
+			start := p.i
+			for p.i < len(p.s) && isBareKeyChar(p.s[p.i]) {
+				p.i++
+			}
+			if p.i == start {
+				return nil, fmt.Errorf("expected a key, got %q", p.rest())
+			}
+			path = append(path, p.s[start:p.i])
+		}
+		p.skipBlank(false)
+		if p.i >= len(p.s) || p.s[p.i] != '.' {
+			return path, nil
+		}
+		p.i++
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func (p *tomlParser) value() (any, error) {
+	p.skipBlank(false)
+	if p.i >= len(p.s) {
+		return nil, fmt.Errorf("expected a value")
+	}
+	switch c := p.s[p.i]; c {
+	case '"', '\'':
+		return p.str(c)
+	case '[':
+		return p.array()
+	case '{':
+		return p.inlineTable()
+	}
+	start := p.i
+	for p.i < len(p.s) && !strings.ContainsRune(" \t\n#,]}", rune(p.s[p.i])) {
+		p.i++
+	}
+	if p.i == start {
+		return nil, fmt.Errorf("expected a value, got %q", p.rest())
+	}
+	return p.s[start:p.i], nil
+}
+
+func (p *tomlParser) array() (any, error) {
+	p.i++ // [
+	items := []any{}
+	for {
+		p.skipBlank(true)
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		if p.s[p.i] == ']' {
+			p.i++
+			return items, nil
+		}
+		v, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+		p.skipBlank(true)
+		if p.i < len(p.s) && p.s[p.i] == ',' {
+			p.i++
+		} else if p.i >= len(p.s) || p.s[p.i] != ']' {
+			return nil, fmt.Errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+func (p *tomlParser) inlineTable() (any, error) {
+	p.i++ // {
+	table := make(map[string]any)
+	for {
+		p.skipBlank(true)
+		if p.i >= len(p.s) {
+			return nil, fmt.Errorf("unterminated inline table")
+		}
+		if p.s[p.i] == '}' {
+			p.i++
+			return table, nil
+		}
+		if err := p.keyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipBlank(true)
+		if p.i < len(p.s) && p.s[p.i] == ',' {
+			p.i++
+		} else if p.i >= len(p.s) || p.s[p.i] != '}' {
+			return nil, fmt.Errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// str parses a basic or literal string, single or multi-line.
+func (p *tomlParser) str(quote byte) (string, error) {
+	multi := strings.Repeat(string(quote), 3)
+	if strings.HasPrefix(p.s[p.i:], multi) {
+		p.i += 3
+		// A newline right after the opening delimiter is trimmed.
+		if p.i < len(p.s) && p.s[p.i] == '\n' {
+			p.i++
+			p.line++
+		}
+		end := strings.Index(p.s[p.i:], multi)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string")
+		}
+		raw := p.s[p.i : p.i+end]
+		p.line += strings.Count(raw, "\n")
+		p.i += end + 3
+		if quote == '\'' {
+			return raw, nil
+		}
+		return unescapeTOML(raw), nil
+	}
+
+	p.i++
+	start := p.i
+	for p.i < len(p.s) && p.s[p.i] != quote {
+		if p.s[p.i] == '\n' {
+			return "", fmt.Errorf("unterminated string")
+		}
+		if quote == '"' && p.s[p.i] == '\\' {
+			p.i++
+		}
+		p.i++
+	}
+	if p.i >= len(p.s) {
+		return "", fmt.Errorf("unterminated string")
+	}
+	raw := p.s[start:p.i]
+	p.i++
+	if quote == '\'' {
+		return raw, nil
+	}
+	return unescapeTOML(raw), nil
+}
+
+// unescapeTOML resolves the escapes of a basic string. A backslash at the
+// end of a line in a multi-line string trims the following whitespace.
+func unescapeTOML(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '\n', ' ', '\t':
+			for i+1 < len(s) && strings.ContainsRune(" \t\n", rune(s[i+1])) {
+				i++
+			}
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
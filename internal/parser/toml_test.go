@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	content := `# pyproject.toml
+[project]
+name = "app"
+version = "1.0.0"
+dependencies = [
+  "requests>=2", # trailing comment
+  'click',
+]
+
+[tool.poe.tasks]
+test = "pytest"
+"check types" = { cmd = "mypy .", help = "Type check" }
+doc.shell = """
+sphinx-build docs \
+  build"""
+
+[[tool.hatch.envs.test.matrix]]
+python = ["3.11", "3.12"]
+
+[[tool.hatch.envs.test.matrix]]
+python = ['3.13']
+debug = true
+`
+	got, err := parseTOML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"project": map[string]any{
+			"name":         "app",
+			"version":      "1.0.0",
+			"dependencies": []any{"requests>=2", "click"},
+		},
+		"tool": map[string]any{
+			"poe": map[string]any{"tasks": map[string]any{
+				"test":        "pytest",
+				"check types": map[string]any{"cmd": "mypy .", "help": "Type check"},
+				"doc":         map[string]any{"shell": "sphinx-build docs build"},
+			}},
+			"hatch": map[string]any{"envs": map[string]any{"test": map[string]any{
+				"matrix": []any{
+					map[string]any{"python": []any{"3.11", "3.12"}},
+					map[string]any{"python": []any{"3.13"}, "debug": "true"},
+				},
+			}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTOML =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	for _, content := range []string{
+		"name = \"app",
+		"[tool\nname = 1",
+		"name\n",
+		"list = [1, 2",
+		"a = 1 b = 2",
+	} {
+		if _, err := parseTOML(content); err == nil {
+			t.Errorf("parseTOML(%q) = nil error", content)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseYAML parses the subset of YAML found in package manager config files:
+// block mappings and sequences, flow sequences and mappings, plain and quoted
+// scalars, comments and block scalars. Mappings are returned as
+// map[string]any, sequences as []any and scalars as string; empty values are
+// nil. Anchors, tags and multi-document streams are not supported.
+func parseYAML(content string) (any, error) {
+	p := &yamlParser{raw: strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")}
+	for i, raw := range p.raw {
+		text := strings.TrimRight(stripYAMLComment(raw), " \t")
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || trimmed == "---" || trimmed == "..." {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+	if len(p.lines) == 0 {
+		return nil, nil
+	}
+	v, err := p.parseBlock(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return v, nil
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string // without indentation and comment
+}
+
+// yamlParser walks the lines holding content, without blank and comment
+// lines; block scalars read the raw lines, where those are content.
+type yamlParser struct {
+	raw   []string
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) peek() (yamlLine, bool) {
+	if p.pos >= len(p.lines) {
+		return yamlLine{}, false
+	}
+	return p.lines[p.pos], true
+}
+
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// parseBlock parses the mapping or sequence starting at the current line.
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	line, _ := p.peek()
+	if isSequenceItem(line.text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+func (p *yamlParser) parseSequence(indent int) (any, error) {
+	items := []any{}
+	for {
+		line, ok := p.peek()
+		if !ok || line.indent != indent || !isSequenceItem(line.text) {
+			return items, nil
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		switch {
+		case rest == "":
+			p.pos++
+			v, err := p.parseNested(indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			items = append(items, p.parseBlockScalar(line, indent, rest[0] == '|'))
+		case isSequenceItem(rest) || yamlKeyEnd(rest) >= 0:
+			// "- key: value" or "- - item": the item is a block starting
+			// right after the dash.
+			itemIndent := indent + len(line.text) - len(rest)
+			p.lines[p.pos] = yamlLine{num: line.num, indent: itemIndent, text: rest}
+			v, err := p.parseBlock(itemIndent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		default:
+			p.pos++
+			v, err := p.parseInline(rest, line)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+	}
+}
+
+func (p *yamlParser) parseMapping(indent int) (any, error) {
+	m := map[string]any{}
+	for {
+		line, ok := p.peek()
+		if !ok || line.indent < indent {
+			return m, nil
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+		if isSequenceItem(line.text) {
+			return m, nil
+		}
+		end := yamlKeyEnd(line.text)
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		key, _ := unquoteYAML(strings.TrimSpace(line.text[:end])).(string)
+		rest := strings.TrimSpace(line.text[end+1:])
+		p.pos++
+
+		var v any
+		var err error
+		switch {
+		case rest == "":
+			v, err = p.parseNested(indent)
+		case strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">"):
+			v = p.parseBlockScalar(line, indent, rest[0] == '|')
+		default:
+			v, err = p.parseInline(rest, line)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m[key] = v
+	}
+}
+
+// parseNested parses the value of a key or dash followed by nothing on its
+// line: a block indented deeper, a sequence at the same indentation as a
+// mapping key, or nothing.
+func (p *yamlParser) parseNested(indent int) (any, error) {
+	next, ok := p.peek()
+	switch {
+	case ok && next.indent > indent:
+		return p.parseBlock(next.indent)
+	case ok && next.indent == indent && isSequenceItem(next.text):
+		return p.parseSequence(indent)
+	}
+	return nil, nil
+}
+
+// parseBlockScalar reads the lines of a "|" or ">" scalar following header,
+// the line of its key or dash at indent, and moves past them. The lines are
+// read raw, so that blank lines and "#" lines are kept, and lose the
+// indentation of the first one. A literal scalar keeps its line breaks; a
+// folded one joins its lines with spaces, blank lines breaking them.
+func (p *yamlParser) parseBlockScalar(header yamlLine, indent int, literal bool) string {
+	var parts []string
+	blockIndent := -1
+	end := header.num // index of the raw line after the scalar
+	for i := header.num; i < len(p.raw); i++ {
+		text := strings.TrimRight(p.raw[i], " \t")
+		if text == "" {
+			parts = append(parts, "")
+			continue
+		}
+		n := len(text) - len(strings.TrimLeft(text, " "))
+		if n <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = n
+		}
+		parts = append(parts, text[min(n, blockIndent):])
+		end = i + 1
+	}
+	parts = parts[:end-header.num] // without trailing blank lines
+	for line, ok := p.peek(); ok && line.num <= end; line, ok = p.peek() {
+		p.pos++
+	}
+
+	if literal {
+		return strings.Join(parts, "\n")
+	}
+	var b strings.Builder
+	for i, part := range parts {
+		switch {
+		case part == "":
+			b.WriteByte('\n')
+		case i > 0 && parts[i-1] != "":
+			b.WriteByte(' ')
+			fallthrough
+		default:
+			b.WriteString(part)
+		}
+	}
+	return b.String()
+}
+
+// parseInline parses a value written on the same line as its key or dash,
+// joining the following lines while a flow collection is left open.
+func (p *yamlParser) parseInline(text string, line yamlLine) (any, error) {
+	if text[0] != '[' && text[0] != '{' {
+		return unquoteYAML(text), nil
+	}
+	for !flowClosed(text) {
+		next, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("line %d: unterminated flow collection", line.num)
+		}
+		text += " " + next.text
+		p.pos++
+	}
+	f := &flowParser{s: text}
+	v, err := f.value()
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", line.num, err)
+	}
+	f.skipSpace()
+	if f.i < len(f.s) {
+		return nil, fmt.Errorf("line %d: unexpected %q after flow collection", line.num, f.s[f.i:])
+	}
+	return v, nil
+}
+
+// yamlKeyEnd returns the index of the colon ending a mapping key in text, or
+// -1 if text is not a "key: value" pair.
+func yamlKeyEnd(text string) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == '[' || c == '{':
+			if i == 0 {
+				return -1
+			}
+		case c == ':' && (i+1 == len(text) || text[i+1] == ' '):
+			return i
+		}
+	}
+	return -1
+}
+
+// stripYAMLComment removes a "#" comment, which starts a line or follows a
+// space, outside quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.ContainsRune(" \t[{,:-", rune(line[i-1])) {
+				quote = c
+			}
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteYAML returns the value of a scalar, removing quotes.
+func unquoteYAML(s string) any {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "" || s == "~" || s == "null":
+		return nil
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return unescapeYAML(s[1 : len(s)-1])
+	}
+	return s
+}
+
+func unescapeYAML(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// flowClosed reports whether every bracket opened in text is closed.
+func flowClosed(text string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// flowParser parses flow collections: [a, "b", {c: d}].
+type flowParser struct {
+	s string
+	i int
+}
+
+func (f *flowParser) skipSpace() {
+	for f.i < len(f.s) && (f.s[f.i] == ' ' || f.s[f.i] == '\t') {
+		f.i++
+	}
+}
+
+func (f *flowParser) value() (any, error) {
+	f.skipSpace()
+	if f.i >= len(f.s) {
+		return nil, fmt.Errorf("unexpected end of flow collection")
+	}
+	switch f.s[f.i] {
+	case '[':
+		return f.sequence()
+	case '{':
+		return f.mapping()
+	}
+	return f.scalar(), nil
+}
+
+func (f *flowParser) sequence() (any, error) {
+	f.i++ // [
+	items := []any{}
+	for {
+		f.skipSpace()
+		if f.i >= len(f.s) {
+			return nil, fmt.Errorf("unterminated flow sequence")
+		}
+		if f.s[f.i] == ']' {
+			f.i++
+			return items, nil
+		}
+		v, err := f.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+		if err := f.separator(']'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (f *flowParser) mapping() (any, error) {
+	f.i++ // {
+	m := map[string]any{}
+	for {
+		f.skipSpace()
+		if f.i >= len(f.s) {
+			return nil, fmt.Errorf("unterminated flow mapping")
+		}
+		if f.s[f.i] == '}' {
+			f.i++
+			return m, nil
+		}
+		key, _ := f.scalar().(string)
+		f.skipSpace()
+		var v any
+		if f.i < len(f.s) && f.s[f.i] == ':' {
+			f.i++
+			var err error
+			if v, err = f.value(); err != nil {
+				return nil, err
+			}
+		}
+		m[key] = v
+		if err := f.separator('}'); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// separator consumes the comma between two entries, leaving the closing
+// bracket for the caller.
+func (f *flowParser) separator(closing byte) error {
+	f.skipSpace()
+	switch {
+	case f.i >= len(f.s):
+		return fmt.Errorf("unterminated flow collection")
+	case f.s[f.i] == ',':
+		f.i++
+	case f.s[f.i] != closing:
+		return fmt.Errorf("expected ',' or '%c', got %q", closing, f.s[f.i])
+	}
+	return nil
+}
+
+// scalar reads a quoted scalar, or a plain one up to the next flow
+// indicator. A colon ends a plain scalar when it is followed by a space, as
+// in {a: b}.
+func (f *flowParser) scalar() any {
+	f.skipSpace()
+	start := f.i
+	if f.i < len(f.s) && (f.s[f.i] == '"' || f.s[f.i] == '\'') {
+		quote := f.s[f.i]
+		f.i++
+		for f.i < len(f.s) {
+			if f.s[f.i] == quote {
+				if quote == '\'' && f.i+1 < len(f.s) && f.s[f.i+1] == '\'' {
+					f.i += 2
+					continue
+				}
+				if quote == '"' && f.s[f.i-1] == '\\' {
+					f.i++
+					continue
+				}
+				break
+			}
+			f.i++
+		}
+		f.i++ // closing quote
+		if f.i > len(f.s) {
+			f.i = len(f.s)
+		}
+		return unquoteYAML(f.s[start:f.i])
+	}
+	for f.i < len(f.s) {
+		c := f.s[f.i]
+		if c == ',' || c == ']' || c == '}' || (c == ':' && (f.i+1 == len(f.s) || f.s[f.i+1] == ' ')) {
+			break
+		}
+		f.i++
+	}
+	return unquoteYAML(f.s[start:f.i])
+}
@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseYAMLBlockScalars(t *testing.T) {
+	content := `tasks:
+  release:
+    cmds: |
+      # tag first
+      git tag v1
+
+        git push --tags   # keeps its comment
+
+    # a comment ending the block
+    desc: >
+      Tag and
+      push
+
+      the release
+  build:
+    cmds:
+      - |
+        go build .
+
+        # then
+        strip app
+      - echo done
+`
+	got, err := parseYAML(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"tasks": map[string]any{
+			"release": map[string]any{
+				"cmds": "# tag first\ngit tag v1\n\n  git push --tags   # keeps its comment",
+				"desc": "Tag and push\nthe release",
+			},
+			"build": map[string]any{
+				"cmds": []any{"go build .\n\n# then\nstrip app", "echo done"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYAML =\n%#v\nwant\n%#v", got, want)
+	}
+}
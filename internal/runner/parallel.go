@@ -0,0 +1,279 @@
+package runner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// Job is a command run alongside others by RunAll.
+type Job struct {
+	Name   string
+	Prefix string // prepended to every output line, e.g. "[build] "
+	Cmd    *exec.Cmd
+	After  []int // indexes of the jobs that must succeed before this one starts
+}
+
+// Result is the outcome of a Job.
+type Result struct {
+	Name     string
+	ExitCode int
+	Err      error
+	Duration time.Duration
+	Killed   bool // stopped by RunAll because another job failed
+	Skipped  bool // never started, because a job it waits for failed or RunAll was stopping
+}
+
+// Options tunes RunAll.
+type Options struct {
+	// KillOthersOnFail terminates every job still running as soon as one
+	// fails, with the same grace period as for forwarded signals.
+	KillOthersOnFail bool
+	// Limit is the maximum number of jobs running at once; 0 means no limit.
+	Limit int
+}
+
+// drainTimeout bounds the waits for a finished job's output, which a process
+// that left its group could keep open.
+const drainTimeout = 200 * time.Millisecond
+
+type jobState int
+
+const (
+	jobPending jobState = iota
+	jobRunning
+	jobDone
+)
+
+// RunAll runs every job in its own process group and waits for all of them.
+// A job starts once the jobs it comes after have succeeded, and is skipped if
+// one of them fails. Their stdout and stderr are written line by line to out,
+// each line carrying the job's prefix. Termination signals are forwarded to
+// every running job, with the same grace period as Run, and no further job is
+// started. Results are in the order of jobs.
+func RunAll(jobs []Job, out io.Writer, opts Options) []Result {
+	results := make([]Result, len(jobs))
+	states := make([]jobState, len(jobs))
+	starts := make([]time.Time, len(jobs))
+	copied := make([]chan struct{}, len(jobs))
+	writers := make([]*prefixWriter, len(jobs))
+	var mu sync.Mutex
+	for i, job := range jobs {
+		results[i].Name = job.Name
+		writers[i] = &prefixWriter{mu: &mu, out: out, prefix: job.Prefix}
+	}
+
+	sigCh := make(chan os.Signal, len(forwardedSignals))
+	signal.Notify(sigCh, forwardedSignals...)
+	defer signal.Stop(sigCh)
+
+	type exit struct {
+		idx int
+		err error
+	}
+	done := make(chan exit, len(jobs))
+	running := make(map[int]*os.Process)
+
+	var (
+		grace       <-chan time.Time
+		stopping    bool // no job is started any more
+		interrupted bool // a signal was forwarded to the jobs
+	)
+	killOthers := func() {
+		stopping = true
+		for i, p := range running {
+			if !results[i].Killed {
+				results[i].Killed = true
+				terminateGroup(p)
+			}
+		}
+		if grace == nil {
+			grace = time.After(GracePeriod)
+		}
+	}
+	finish := func(i int, err error) {
+		states[i] = jobDone
+		results[i].Err = err
+		results[i].ExitCode = ExitCode(err)
+		if err == nil {
+			// It had exited on its own before being stopped.
+			results[i].Killed = false
+		}
+		if err != nil && !results[i].Killed && !interrupted && opts.KillOthersOnFail {
+			killOthers()
+		}
+	}
+
+	// Output goes through pipes we own rather than through exec's copying,
+	// so that waiting for a job does not also wait for processes it left
+	// behind holding the pipe open; those are killed with its group.
+	var copying sync.WaitGroup
+	start := func(i int) {
+		cmd := jobs[i].Cmd
+		starts[i] = time.Now()
+		pr, pw, err := os.Pipe()
+		if err != nil {
+			finish(i, err)
+			return
+		}
+		cmd.Stdout = pw
+		cmd.Stderr = pw
+		setGroup(cmd, -1)
+
+		err = cmd.Start()
+		pw.Close()
+		if err != nil {
+			pr.Close()
+			finish(i, err)
+			return
+		}
+		states[i] = jobRunning
+		running[i] = cmd.Process
+		copied[i] = make(chan struct{})
+		copying.Add(1)
+		go func(w *prefixWriter, copied chan struct{}) {
+			defer copying.Done()
+			defer close(copied)
+			defer pr.Close()
+			_, _ = io.Copy(w, pr)
+		}(writers[i], copied[i])
+		go func() {
+			done <- exit{i, cmd.Wait()}
+		}()
+	}
+
+	// schedule starts every pending job that may run now, and skips those
+	// that never will.
+	schedule := func() {
+		for changed := true; changed; {
+			changed = false
+			for i, job := range jobs {
+				if states[i] != jobPending {
+					continue
+				}
+				blocked, failed := false, false
+				for _, j := range job.After {
+					switch {
+					case states[j] != jobDone:
+						blocked = true
+					case results[j].Err != nil || results[j].Skipped:
+						failed = true
+					}
+				}
+				switch {
+				case stopping || failed:
+					states[i] = jobDone
+					results[i].Skipped = true
+				case blocked || (opts.Limit > 0 && len(running) >= opts.Limit):
+					continue
+				default:
+					start(i)
+				}
+				changed = true
+			}
+		}
+	}
+
+	schedule()
+	for len(running) > 0 {
+		select {
+		case e := <-done:
+			delete(running, e.idx)
+			killGroup(jobs[e.idx].Cmd.Process)
+			results[e.idx].Duration = time.Since(starts[e.idx])
+			// Let the job's last lines out before the jobs waiting for it
+			// start printing theirs.
+			select {
+			case <-copied[e.idx]:
+			case <-time.After(drainTimeout):
+			}
+			finish(e.idx, e.err)
+		case sig := <-sigCh:
+			interrupted, stopping = true, true
+			for _, p := range running {
+				signalGroup(p, sig)
+			}
+			if grace == nil {
+				grace = time.After(GracePeriod)
+			}
+		case <-grace:
+			for _, p := range running {
+				killGroup(p)
+			}
+		}
+		schedule()
+	}
+
+	// Jobs still pending wait on each other and can never start.
+	for i := range jobs {
+		if states[i] == jobPending {
+			results[i].Skipped = true
+		}
+	}
+
+	// Output still held open by processes that left their group is dropped
+	// after the same wait as for a finished job.
+	drained := make(chan struct{})
+	go func() {
+		copying.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(drainTimeout):
+	}
+	for _, w := range writers {
+		w.close()
+	}
+	return results
+}
+
+// prefixWriter writes complete lines to out, each preceded by prefix. Writers
+// sharing mu never interleave within a line.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+	closed bool
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, io.ErrClosedPipe
+	}
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if _, err := io.WriteString(w.out, w.prefix); err != nil {
+			return 0, err
+		}
+		if _, err := w.out.Write(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// close writes a trailing partial line, if any, and fails later writes.
+func (w *prefixWriter) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	if len(w.buf) > 0 {
+		io.WriteString(w.out, w.prefix)
+		w.out.Write(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
@@ -0,0 +1,194 @@
+package runner
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunAllPrefixesOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	var out bytes.Buffer
+	results := RunAll([]Job{
+		{Name: "a", Prefix: "[a] ", Cmd: exec.Command("sh", "-c", "echo one; echo two >&2")},
+		{Name: "b", Prefix: "[b] ", Cmd: exec.Command("sh", "-c", "printf 'partial'; exit 4")},
+	}, &out, Options{})
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Name != "a" || results[0].ExitCode != 0 {
+		t.Errorf("results[0] = %+v, want a/0", results[0])
+	}
+	if results[1].Name != "b" || results[1].ExitCode != 4 {
+		t.Errorf("results[1] = %+v, want b/4", results[1])
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	sort.Strings(lines)
+	want := []string{"[a] one", "[a] two", "[b] partial"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("output lines = %q, want %q", lines, want)
+	}
+}
+
+func TestRunAllDoesNotWaitForLeftovers(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	start := time.Now()
+	results := RunAll([]Job{
+		{Name: "bg", Cmd: exec.Command("sh", "-c", "sleep 30 & echo started")},
+	}, &bytes.Buffer{}, Options{})
+
+	if results[0].ExitCode != 0 {
+		t.Errorf("ExitCode = %d, want 0", results[0].ExitCode)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("RunAll took %v, should not wait for the background sleep", elapsed)
+	}
+}
+
+func TestRunAllDoesNotWaitForDetachedOutput(t *testing.T) {
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("requires setsid")
+	}
+	start := time.Now()
+	results := RunAll([]Job{
+		{Name: "detached", Cmd: exec.Command("sh", "-c", "setsid sleep 5 & echo started")},
+	}, &bytes.Buffer{}, Options{})
+
+	if results[0].ExitCode != 0 {
+		t.Errorf("ExitCode = %d, want 0", results[0].ExitCode)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("RunAll took %v, should not wait for the detached sleep holding the output", elapsed)
+	}
+}
+
+func TestRunAllStartFailure(t *testing.T) {
+	results := RunAll([]Job{
+		{Name: "missing", Cmd: exec.Command("skit-definitely-not-a-command")},
+	}, &bytes.Buffer{}, Options{})
+
+	if results[0].ExitCode != 127 || results[0].Err == nil {
+		t.Errorf("result = %+v, want exit code 127 with an error", results[0])
+	}
+}
+
+func TestRunAllKillOthersOnFail(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	start := time.Now()
+	results := RunAll([]Job{
+		{Name: "slow", Cmd: exec.Command("sh", "-c", "sleep 30")},
+		{Name: "fail", Cmd: exec.Command("sh", "-c", "sleep 0.1; exit 2")},
+	}, &bytes.Buffer{}, Options{KillOthersOnFail: true})
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("RunAll took %v, the slow job should have been killed", elapsed)
+	}
+	if !results[0].Killed || results[0].ExitCode != 128+15 {
+		t.Errorf("slow = %+v, want killed with SIGTERM", results[0])
+	}
+	if results[1].Killed || results[1].ExitCode != 2 {
+		t.Errorf("fail = %+v, want exit code 2, not killed", results[1])
+	}
+}
+
+func TestRunAllKillOthersSparesFinishedJobs(t *testing.T) {
+	if _, err := exec.LookPath("setsid"); err != nil {
+		t.Skip("requires setsid")
+	}
+	// The failing job's output stays open while the other one exits, so
+	// that one has exited but is not collected yet when the others are
+	// stopped.
+	results := RunAll([]Job{
+		{Name: "fail", Cmd: exec.Command("sh", "-c", "setsid sleep 1 & exit 2")},
+		{Name: "ok", Cmd: exec.Command("sh", "-c", "sleep 0.05")},
+	}, &bytes.Buffer{}, Options{KillOthersOnFail: true})
+
+	if results[0].ExitCode != 2 {
+		t.Errorf("fail = %+v, want exit code 2", results[0])
+	}
+	if results[1].Killed || results[1].Err != nil {
+		t.Errorf("ok = %+v, want a clean exit, not killed", results[1])
+	}
+}
+
+func TestRunAllWaitsForDependencies(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	var out bytes.Buffer
+	results := RunAll([]Job{
+		{Name: "app", Cmd: exec.Command("sh", "-c", "echo app"), After: []int{1}},
+		{Name: "lib", Cmd: exec.Command("sh", "-c", "sleep 0.2; echo lib")},
+		{Name: "broken", Cmd: exec.Command("sh", "-c", "exit 1")},
+		{Name: "docs", Cmd: exec.Command("sh", "-c", "echo docs"), After: []int{2}},
+	}, &out, Options{})
+
+	if got := out.String(); !strings.Contains(got, "lib\napp\n") {
+		t.Errorf("app should start after lib finished, output:\n%s", got)
+	}
+	if results[0].Skipped || results[0].ExitCode != 0 {
+		t.Errorf("app = %+v, want run successfully", results[0])
+	}
+	if !results[3].Skipped || strings.Contains(out.String(), "docs") {
+		t.Errorf("docs = %+v, want skipped after broken failed", results[3])
+	}
+}
+
+func TestRunAllLimit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	// Each job holds a marker file while it runs and reports finding
+	// another job's marker.
+	busy := filepath.Join(t.TempDir(), "busy")
+	script := "[ -e " + busy + " ] && echo overlap; touch " + busy + "; sleep 0.1; rm " + busy
+	var out bytes.Buffer
+	var jobs []Job
+	for _, name := range []string{"a", "b", "c"} {
+		jobs = append(jobs, Job{Name: name, Cmd: exec.Command("sh", "-c", script)})
+	}
+	results := RunAll(jobs, &out, Options{Limit: 1})
+
+	for _, r := range results {
+		if r.ExitCode != 0 {
+			t.Errorf("%s exited with %d", r.Name, r.ExitCode)
+		}
+	}
+	if strings.Contains(out.String(), "overlap") {
+		t.Errorf("with Limit 1 jobs should not overlap, output:\n%s", out.String())
+	}
+}
+
+func TestPrefixWriterKeepsLinesWhole(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	a := &prefixWriter{mu: &mu, out: &out, prefix: "a| "}
+	b := &prefixWriter{mu: &mu, out: &out, prefix: "b| "}
+
+	a.Write([]byte("hel"))
+	b.Write([]byte("world\n"))
+	a.Write([]byte("lo\nbye"))
+	a.close()
+	if _, err := a.Write([]byte("late\n")); err == nil {
+		t.Error("Write after close succeeded")
+	}
+
+	want := "b| world\na| hello\na| bye\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,108 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var forwardedSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT}
+
+// Run starts cmd in its own process group and waits for it to exit.
+//
+// When skit owns the terminal, the group is made the foreground group so the
+// script can read from it and receives Ctrl+C directly. SIGINT, SIGTERM,
+// SIGHUP and SIGQUIT sent to skit are forwarded to the group; if it is still
+// running GracePeriod after the first one, it is killed. Processes left in
+// the group once the script exits are killed too, so no dev server survives
+// its parent.
+func Run(cmd *exec.Cmd) error {
+	tty := foregroundTTY()
+	setGroup(cmd, tty)
+
+	sigCh := make(chan os.Signal, len(forwardedSignals))
+	signal.Notify(sigCh, forwardedSignals...)
+	defer signal.Stop(sigCh)
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if tty >= 0 {
+		defer reclaimForeground(tty)
+	}
+	defer killGroup(cmd.Process)
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var grace <-chan time.Time
+	for {
+		select {
+		case err := <-done:
+			return err
+		case sig := <-sigCh:
+			signalGroup(cmd.Process, sig)
+			if grace == nil {
+				grace = time.After(GracePeriod)
+			}
+		case <-grace:
+			killGroup(cmd.Process)
+		}
+	}
+}
+
+// setGroup makes cmd start in a new process group, in the foreground of the
+// terminal tty unless tty is negative.
+func setGroup(cmd *exec.Cmd, tty int) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if tty >= 0 {
+		cmd.SysProcAttr.Foreground = true
+		cmd.SysProcAttr.Ctty = tty
+	}
+}
+
+// signalGroup sends sig to the process group led by p.
+func signalGroup(p *os.Process, sig os.Signal) {
+	if s, ok := sig.(syscall.Signal); ok {
+		_ = syscall.Kill(-p.Pid, s)
+	}
+}
+
+// terminateGroup asks every process in the group led by p to stop.
+func terminateGroup(p *os.Process) {
+	_ = syscall.Kill(-p.Pid, syscall.SIGTERM)
+}
+
+// killGroup kills every process in the group led by p.
+func killGroup(p *os.Process) {
+	_ = syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
+
+// foregroundTTY returns the stdin file descriptor when it is a terminal whose
+// foreground process group is skit's own, or -1 otherwise.
+func foregroundTTY() int {
+	fd := int(os.Stdin.Fd())
+	var pgrp int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCGPGRP, uintptr(unsafe.Pointer(&pgrp))); errno != 0 {
+		return -1
+	}
+	if int(pgrp) != syscall.Getpgrp() {
+		return -1
+	}
+	return fd
+}
+
+// reclaimForeground makes skit's process group the terminal's foreground
+// group again. SIGTTOU is ignored meanwhile, as skit is a background process
+// at that point and would otherwise be stopped.
+func reclaimForeground(fd int) {
+	signal.Ignore(syscall.SIGTTOU)
+	defer signal.Reset(syscall.SIGTTOU)
+	pgrp := int32(syscall.Getpgrp())
+	syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.TIOCSPGRP, uintptr(unsafe.Pointer(&pgrp)))
+}
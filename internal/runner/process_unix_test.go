@@ -0,0 +1,95 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func waitForFile(t *testing.T, path string) string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+			return strings.TrimSpace(string(data))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", path)
+	return ""
+}
+
+func TestRunKillsLeftoverProcesses(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	cmd := exec.Command("sh", "-c", "sleep 30 & echo $! > "+pidFile)
+	if err := Run(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	pid, err := strconv.Atoi(waitForFile(t, pidFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for syscall.Kill(pid, 0) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("background process %d survived the script", pid)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRunForwardsSignals(t *testing.T) {
+	ready := filepath.Join(t.TempDir(), "ready")
+	cmd := exec.Command("sh", "-c", `trap "exit 42" TERM; echo ok > `+ready+`; while :; do sleep 0.05; done`)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- Run(cmd) }()
+
+	waitForFile(t, ready)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if code := ExitCode(err); code != 42 {
+			t.Errorf("ExitCode = %d, want 42 from the script's TERM trap", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("script did not exit after SIGTERM was forwarded")
+	}
+}
+
+func TestRunKillsAfterGracePeriod(t *testing.T) {
+	old := GracePeriod
+	GracePeriod = 100 * time.Millisecond
+	defer func() { GracePeriod = old }()
+
+	ready := filepath.Join(t.TempDir(), "ready")
+	cmd := exec.Command("sh", "-c", `trap "" TERM; echo ok > `+ready+`; while :; do sleep 0.05; done`)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- Run(cmd) }()
+
+	waitForFile(t, ready)
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		if code := ExitCode(err); code != 128+int(syscall.SIGKILL) {
+			t.Errorf("ExitCode = %d, want %d", code, 128+int(syscall.SIGKILL))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("script survived the grace period")
+	}
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+)
+
+// Console Ctrl+C events reach every process attached to the console, so there
+// is nothing to forward; skit only has to stay alive to report exit statuses.
+var forwardedSignals = []os.Signal{os.Interrupt}
+
+// Run starts cmd and waits for it to exit.
+func Run(cmd *exec.Cmd) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, forwardedSignals...)
+	defer signal.Stop(sigCh)
+	return cmd.Run()
+}
+
+func setGroup(cmd *exec.Cmd, tty int) {}
+
+func signalGroup(p *os.Process, sig os.Signal) {}
+
+func terminateGroup(p *os.Process) {
+	_ = p.Kill()
+}
+
+func killGroup(p *os.Process) {
+	_ = p.Kill()
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"errors"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// GracePeriod is how long a script gets to exit after a termination signal
+// has been forwarded to it before its whole process group is killed.
+var GracePeriod = 5 * time.Second
+
+// ExitCode converts the error returned by running a command into a process
+// exit status: the child's own code, 128+N when it was killed by signal N
+// (as shells report it), 127 when the executable was not found, and 1 for
+// any other failure.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		if code := exitErr.ExitCode(); code > 0 {
+			return code
+		}
+		return 1
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		return 127
+	}
+	return 1
+}
@@ -0,0 +1,47 @@
+package runner
+
+import (
+	"errors"
+	"os/exec"
+	"runtime"
+	"testing"
+)
+
+func TestExitCodeNil(t *testing.T) {
+	if code := ExitCode(nil); code != 0 {
+		t.Errorf("ExitCode(nil) = %d, want 0", code)
+	}
+}
+
+func TestExitCodeFromChild(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	err := exec.Command("sh", "-c", "exit 3").Run()
+	if code := ExitCode(err); code != 3 {
+		t.Errorf("ExitCode = %d, want 3", code)
+	}
+}
+
+func TestExitCodeFromSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+	err := exec.Command("sh", "-c", "kill -TERM $$").Run()
+	if code := ExitCode(err); code != 143 {
+		t.Errorf("ExitCode = %d, want 143 (128+SIGTERM)", code)
+	}
+}
+
+func TestExitCodeNotFound(t *testing.T) {
+	err := exec.Command("skit-definitely-not-a-command").Run()
+	if code := ExitCode(err); code != 127 {
+		t.Errorf("ExitCode = %d, want 127", code)
+	}
+}
+
+func TestExitCodeOtherError(t *testing.T) {
+	if code := ExitCode(errors.New("boom")); code != 1 {
+		t.Errorf("ExitCode = %d, want 1", code)
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import "unicode"
+
+// Scoring weights for fuzzyMatch.
+const (
+	scoreMatch       = 16
+	bonusPrefix      = 24 // match on the first character
+	bonusSeparator   = 20 // match right after ':', the script group separator
+	bonusBoundary    = 16 // match right after another word separator
+	bonusCamel       = 12 // match on a lower-to-upper case transition
+	bonusConsecutive = 12 // match right after the previous match, at least the bonus the run started with
+	penaltyGapStart  = 3
+	penaltyGapExtra  = 1
+)
+
+// fuzzyMatch reports whether pattern is a case-insensitive subsequence of
+// text and scores the best alignment: matches at the start of the text, after
+// separators and in a row score higher, gaps between matches cost a little.
+// positions holds the rune indices of the matched characters in text.
+func fuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	if len(p) > len(t) {
+		return 0, nil, false
+	}
+
+	pl := make([]rune, len(p))
+	for i, r := range p {
+		pl[i] = unicode.ToLower(r)
+	}
+	tl := make([]rune, len(t))
+	for i, r := range t {
+		tl[i] = unicode.ToLower(r)
+	}
+
+	// best[i][j] is the best score for p[:i+1] with p[i] matched at t[j];
+	// from[i][j] is where p[i-1] was matched on that path and run[i][j] the
+	// bonus of the first character of the consecutive run ending at t[j].
+	const none = -1 << 30
+	best := make([][]int, len(p))
+	from := make([][]int, len(p))
+	run := make([][]int, len(p))
+	for i := range p {
+		best[i] = make([]int, len(t))
+		from[i] = make([]int, len(t))
+		run[i] = make([]int, len(t))
+		for j := range t {
+			best[i][j] = none
+			if tl[j] != pl[i] {
+				continue
+			}
+			bonus := positionBonus(t, j)
+			if i == 0 {
+				best[i][j] = scoreMatch + bonus
+				run[i][j] = bonus
+				continue
+			}
+			for k := i - 1; k < j; k++ {
+				if best[i-1][k] == none {
+					continue
+				}
+				s := best[i-1][k] + scoreMatch + bonus
+				runBonus := bonus
+				if gap := j - k - 1; gap == 0 {
+					runBonus = max(run[i-1][k], bonusConsecutive)
+					s += runBonus
+				} else {
+					s -= penaltyGapStart + penaltyGapExtra*(gap-1)
+				}
+				if s > best[i][j] {
+					best[i][j] = s
+					from[i][j] = k
+					run[i][j] = runBonus
+				}
+			}
+		}
+	}
+
+	last := len(p) - 1
+	end := -1
+	for j := range t {
+		if best[last][j] != none && (end < 0 || best[last][j] > best[last][end]) {
+			end = j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions = make([]int, len(p))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return best[last][end], positions, true
+}
+
+// positionBonus rewards matching the character at t[j] based on what precedes it.
+func positionBonus(t []rune, j int) int {
+	if j == 0 {
+		return bonusPrefix
+	}
+	prev := t[j-1]
+	switch {
+	case prev == ':':
+		return bonusSeparator
+	case prev == '-' || prev == '_' || prev == ' ' || prev == '/' || prev == '.' || prev == '@':
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(t[j]):
+		return bonusCamel
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package ui
+
+import "testing"
+
+func TestFuzzyMatchSubsequence(t *testing.T) {
+	score, positions, ok := fuzzyMatch("tw", "test:watch")
+	if !ok {
+		t.Fatal("expected 'tw' to match 'test:watch'")
+	}
+	if score <= 0 {
+		t.Errorf("score = %d, want positive", score)
+	}
+	want := []int{0, 5}
+	if len(positions) != len(want) || positions[0] != want[0] || positions[1] != want[1] {
+		t.Errorf("positions = %v, want %v", positions, want)
+	}
+}
+
+func TestFuzzyMatchNoMatch(t *testing.T) {
+	for _, tt := range []struct{ pattern, text string }{
+		{"xyz", "test:watch"},
+		{"wt", "tw"},
+		{"build:all", "build"},
+	} {
+		if _, _, ok := fuzzyMatch(tt.pattern, tt.text); ok {
+			t.Errorf("fuzzyMatch(%q, %q) matched, want no match", tt.pattern, tt.text)
+		}
+	}
+}
+
+func TestFuzzyMatchCaseInsensitive(t *testing.T) {
+	if _, _, ok := fuzzyMatch("TW", "test:watch"); !ok {
+		t.Error("expected case-insensitive match")
+	}
+}
+
+func TestFuzzyMatchEmptyPattern(t *testing.T) {
+	if _, _, ok := fuzzyMatch("", "anything"); !ok {
+		t.Error("empty pattern should match everything")
+	}
+}
+
+func TestFuzzyMatchRanking(t *testing.T) {
+	tests := []struct {
+		pattern, better, worse string
+	}{
+		{"build", "build", "prebuild"},       // prefix
+		{"tw", "test:watch", "outward"},      // ':' separator
+		{"lf", "lint:fix", "self"},           // separator beats mid-word
+		{"dev", "dev:api", "db:dev-setup"},   // prefix beats later boundary
+		{"tc", "typeCheck", "attic"},         // camelCase boundary
+		{"watch", "test:watch", "w-a-t-c-h"}, // consecutive beats scattered
+	}
+
+	for _, tt := range tests {
+		sb, _, okb := fuzzyMatch(tt.pattern, tt.better)
+		sw, _, okw := fuzzyMatch(tt.pattern, tt.worse)
+		if !okb || !okw {
+			t.Errorf("%q should match both %q and %q", tt.pattern, tt.better, tt.worse)
+			continue
+		}
+		if sb <= sw {
+			t.Errorf("%q: score(%q)=%d should beat score(%q)=%d", tt.pattern, tt.better, sb, tt.worse, sw)
+		}
+	}
+}
+
+func TestFuzzyMatchPrefersBoundaryAlignment(t *testing.T) {
+	// "tw" could align on "t...w" inside "test" but the ':' boundary wins
+	_, positions, ok := fuzzyMatch("tw", "tt:watch")
+	if !ok {
+		t.Fatal("expected a match")
+	}
+	if positions[1] != 3 {
+		t.Errorf("positions = %v, want the 'w' after ':'", positions)
+	}
+}
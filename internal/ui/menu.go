@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
+	"sort"
 	"strings"
 	"syscall"
 
@@ -20,27 +22,269 @@ type Options struct {
 	ColorPalette  []string
 	CustomUpKey   byte
 	CustomDownKey byte
+	Recent        []string // script names to pin in a "Recent" section, best first
+	Last          string   // script to place the cursor on initially
 }
 
+// maxRecent is the number of scripts pinned in the "Recent" section.
+const maxRecent = 5
+
 // SelectionResult holds the user's script selection.
 type SelectionResult struct {
-	Script    *parser.Script
+	Script    *parser.Script  // first selected script
+	Scripts   []parser.Script // every selected script, in selection order
 	Confirmed bool
 }
 
-// Run displays the interactive menu and returns the user's selection.
+// Item is a row of a generic menu built with Select.
+type Item struct {
+	Name   string   // main column
+	Detail string   // secondary column, shown in gray
+	Prefix string   // pre-rendered column shown before the name
+	Search []string // text matched by the filter besides Name
+}
+
+// List describes a generic menu.
+type List struct {
+	Title          string
+	Items          []Item
+	Hint           string // extra key hint appended to the help line
+	CountFmt       string // "(%d/%d ...)" position shown when the list scrolls
+	Empty          string // shown when the filter matches nothing
+	FallbackPrompt string // prompt of the numbered fallback menu
+	Initial        int    // index of the item under the cursor when the menu opens
+	// OnRemove, when set, lets Delete or Backspace remove the item under the
+	// cursor, once RemovePrompt is answered with yes. It returns whether the
+	// item was removed.
+	OnRemove     func(index int) bool
+	RemovePrompt string // shown in place of the help line; %s is the item's name
+}
+
+// Select displays a generic menu and returns the index of the chosen item.
+func Select(l List, opts Options) (int, bool) {
+	rows := make([]row, len(l.Items))
+	for i, it := range l.Items {
+		rows[i] = row{
+			id:     i,
+			name:   it.Name,
+			detail: it.Detail,
+			prefix: it.Prefix,
+			search: append([]string{it.Name}, it.Search...),
+		}
+	}
+	ids, ok := runMenu(menuSpec{
+		title:          l.Title,
+		hint:           l.Hint,
+		countFmt:       l.CountFmt,
+		empty:          l.Empty,
+		fallbackTitle:  l.Title,
+		fallbackPrompt: l.FallbackPrompt,
+		rows:           rows,
+		initial:        l.Initial,
+		onRemove:       l.OnRemove,
+		removePrompt:   l.RemovePrompt,
+	}, opts)
+	if !ok {
+		return 0, false
+	}
+	return ids[0], true
+}
+
+// Run displays the interactive menu and returns the user's selection. Space
+// toggles scripts to select several at once.
 func Run(scripts []parser.Script, opts Options) SelectionResult {
 	if len(scripts) == 0 {
 		return SelectionResult{}
 	}
 
+	m := i18n.Get()
+	byName := make(map[string]int, len(scripts))
+	for i, s := range scripts {
+		if _, ok := byName[s.Name]; !ok {
+			byName[s.Name] = i
+		}
+	}
+
+	// Pinned rows repeat scripts from the full list; rows are identified by
+	// the index of their script.
+	// Scripts from several sources, such as package.json and a Makefile,
+	// show their source as a badge.
+	badges := false
+	for _, s := range scripts {
+		badges = badges || s.Source != scripts[0].Source
+	}
+	newRow := func(i int) row {
+		r := scriptRow(i, scripts[i])
+		if badges {
+			r.badge = scripts[i].Source
+		}
+		return r
+	}
+
+	var rows []row
+	for _, name := range opts.Recent {
+		if len(rows) == maxRecent {
+			break
+		}
+		if i, ok := byName[name]; ok {
+			r := newRow(i)
+			r.section = m.MenuRecent
+			r.pinned = true
+			rows = append(rows, r)
+		}
+	}
+	section := ""
+	if len(rows) > 0 {
+		section = m.MenuAllScripts
+	}
+	for i := range scripts {
+		r := newRow(i)
+		if r.section == "" {
+			r.section = section
+		}
+		rows = append(rows, r)
+	}
+
+	// The cursor indexes the listed rows, which leave the hidden ones out.
+	initial, listed := 0, 0
+	for _, r := range rows {
+		if r.hidden && !r.pinned {
+			continue
+		}
+		if r.name == opts.Last {
+			initial = listed
+			break
+		}
+		listed++
+	}
+
+	ids, ok := runMenu(menuSpec{
+		title:          m.MenuTitle,
+		hint:           m.MenuMultiHint,
+		countFmt:       m.ScriptCount,
+		empty:          m.NoMatchingScripts,
+		fallbackTitle:  m.FallbackTitle,
+		fallbackPrompt: m.FallbackPrompt,
+		rows:           rows,
+		initial:        initial,
+		multi:          true,
+	}, opts)
+	if !ok {
+		return SelectionResult{}
+	}
+	selected := make([]parser.Script, len(ids))
+	for i, id := range ids {
+		selected[i] = scripts[id]
+	}
+	return SelectionResult{Script: &selected[0], Scripts: selected, Confirmed: true}
+}
+
+func scriptRow(id int, s parser.Script) row {
+	// Show description (from x-skit) or command as description
+	desc := s.Description
+	if desc == "" {
+		desc = s.Command
+	}
+	// Deno tasks list the tasks they run first.
+	if len(s.Dependencies) > 0 {
+		deps := fmt.Sprintf(i18n.Get().MenuDependsOn, strings.Join(s.Dependencies, ", "))
+		if desc == "" {
+			desc = deps
+		} else {
+			desc += "  · " + deps
+		}
+	}
+	// npm runs "prebuild" and "postbuild" along with "build": the hooks are
+	// listed under their script rather than on their own.
+	var hooks, expand []string
+	width := len("post" + s.Name)
+	if s.Pre != "" {
+		hooks = append(hooks, "pre")
+		expand = append(expand, fmt.Sprintf("↺ %-*s  %s", width, "pre"+s.Name, s.Pre))
+	}
+	if s.Post != "" {
+		hooks = append(hooks, "post")
+		expand = append(expand, fmt.Sprintf("↺ %-*s  %s", width, "post"+s.Name, s.Post))
+	}
+	if len(hooks) > 0 {
+		desc += "  ↺ " + strings.Join(hooks, "/")
+	}
+	if len(s.Tags) > 0 {
+		desc += "  #" + strings.Join(s.Tags, " #")
+	}
+	r := row{
+		id:     id,
+		name:   s.Name,
+		detail: desc,
+		expand: expand,
+		hidden: s.HookOf != "" || s.Hidden,
+		search: []string{s.Name, s.Command, s.Description, s.Source, strings.Join(s.Tags, " "), strings.Join(s.Aliases, " ")},
+	}
+	// Scripts of several packages are grouped under the package name, which
+	// moves into the row once filtering hides the section headers.
+	if s.Workspace != "" {
+		r.section = s.Workspace
+		r.context = s.Workspace + " › "
+		r.search[0] = r.context + s.Name
+	}
+	return r
+}
+
+// row is a single menu line.
+type row struct {
+	id      int // what runMenu returns; pinned rows share it with their original
+	name    string
+	detail  string
+	prefix  string
+	badge   string   // script source, shown between the name and the detail
+	expand  []string // lines shown under the row while the cursor is on it
+	search  []string
+	section string // header printed above the first row of each section
+	context string // printed before the name when section headers are hidden
+	pinned  bool   // shortcut row, hidden while filtering
+	hidden  bool   // hook or x-skit hidden script, shown only while filtering
+}
+
+// label returns the name shown for r, with its context when section headers
+// are hidden.
+func (r row) label(sections bool) string {
+	if sections {
+		return r.name
+	}
+	return r.context + r.name
+}
+
+// menuSpec holds what differs between the menus built on runMenu.
+type menuSpec struct {
+	title          string
+	hint           string
+	countFmt       string
+	empty          string
+	fallbackTitle  string
+	fallbackPrompt string
+	rows           []row
+	initial        int  // row under the cursor when the menu opens
+	multi          bool // Space toggles rows to select several
+	onRemove       func(id int) bool
+	removePrompt   string
+}
+
+// runMenu drives a raw-mode menu over spec.rows and returns the ids of the
+// selected rows: the toggled ones in toggle order in multi-select mode, or
+// the one under the cursor. It falls back to a numbered prompt when raw mode
+// is unavailable.
+func runMenu(spec menuSpec, opts Options) ([]int, bool) {
+	if len(spec.rows) == 0 {
+		return nil, false
+	}
+
 	if opts.KeyScheme == "" {
 		opts.KeyScheme = config.KeySchemeArrows
 	}
 
 	oldState, err := makeRaw()
 	if err != nil {
-		return runFallbackMenu(scripts, opts.ColorPalette)
+		return runFallbackMenu(spec, opts.ColorPalette)
 	}
 	defer restoreTerminal(oldState)
 
@@ -62,16 +306,28 @@ func Run(scripts []parser.Script, opts Options) SelectionResult {
 	fmt.Print(ansi.HideCursor)
 	defer fmt.Print(ansi.ShowCursor)
 
-	cursor := 0
-	scroll := 0
+	// alive holds the indices in spec.rows of the rows not removed by onRemove
+	alive := make([]int, len(spec.rows))
+	for i := range alive {
+		alive[i] = i
+	}
+
 	maxVisible := 15
+	cursor := spec.initial
+	scroll := 0
+	if cursor >= maxVisible {
+		scroll = cursor - maxVisible + 1
+	}
 	filter := ""
 	filtering := false
-	filtered := scripts
+	var filtered []int
+	var highlights map[int][]int
+	var toggled []int
+	removing := false // waiting for the removal of the row under the cursor to be confirmed
 	prevLines := 0
 
 	for {
-		filtered = applyFilter(scripts, filter)
+		filtered, highlights = applyFilter(spec.rows, alive, filter)
 		if cursor >= len(filtered) {
 			if len(filtered) == 0 {
 				cursor = 0
@@ -79,20 +335,46 @@ func Run(scripts []parser.Script, opts Options) SelectionResult {
 				cursor = len(filtered) - 1
 			}
 		}
+		if scroll > cursor {
+			scroll = cursor
+		}
 
-		prevLines = renderMenu(filtered, cursor, scroll, maxVisible, filter, filtering, prevLines, opts)
+		prompt := ""
+		if removing {
+			prompt = fmt.Sprintf(spec.removePrompt, spec.rows[filtered[cursor]].name)
+		}
+		prevLines = renderMenu(spec, filtered, highlights, toggled, cursor, scroll, maxVisible, filter, filtering, prompt, prevLines, opts)
 
 		b := make([]byte, 4)
 		n, err := os.Stdin.Read(b)
 		if err != nil {
 			clearLines(prevLines)
-			return SelectionResult{}
+			return nil, false
 		}
 		if n == 0 {
 			continue
 		}
 		key := b[:n]
 
+		if removing {
+			// Anything but yes cancels, without acting on the key.
+			removing = false
+			answer := strings.ToLower(string(key[:1]))
+			if !slices.Contains(strings.Fields(i18n.Get().ConfirmYes), answer) {
+				continue
+			}
+			id := spec.rows[filtered[cursor]].id
+			if spec.onRemove(id) {
+				alive = removeID(spec.rows, alive, id)
+				toggled = removeValue(toggled, id)
+				if len(alive) == 0 {
+					clearLines(prevLines)
+					return nil, false
+				}
+			}
+			continue
+		}
+
 		if filtering {
 			switch {
 			case key[0] == 27: // Escape
@@ -115,19 +397,32 @@ func Run(scripts []parser.Script, opts Options) SelectionResult {
 		switch {
 		case isQuitKey(key[0], opts):
 			clearLines(prevLines)
-			return SelectionResult{}
+			return nil, false
 
 		case key[0] == '/':
 			filtering = true
 			filter = ""
 
 		case key[0] == 13: // Enter
+			if len(toggled) > 0 {
+				clearLines(prevLines)
+				return toggled, true
+			}
 			if len(filtered) == 0 {
 				continue
 			}
-			selected := filtered[cursor]
 			clearLines(prevLines)
-			return SelectionResult{Script: &selected, Confirmed: true}
+			return []int{spec.rows[filtered[cursor]].id}, true
+
+		case key[0] == ' ' && spec.multi:
+			if len(filtered) == 0 {
+				continue
+			}
+			toggled = toggle(toggled, spec.rows[filtered[cursor]].id)
+			moveDown(&cursor, &scroll, maxVisible, len(filtered))
+
+		case spec.onRemove != nil && len(filtered) > 0 && isRemoveKey(key):
+			removing = true
 
 		case n >= 3 && key[0] == 27 && key[1] == 91:
 			switch key[2] {
@@ -142,8 +437,40 @@ func Run(scripts []parser.Script, opts Options) SelectionResult {
 
 		case isDownKey(key[0], opts):
 			moveDown(&cursor, &scroll, maxVisible, len(filtered))
+
+		}
+	}
+}
+
+// removeID returns the row indices whose row does not have the given id.
+func removeID(rows []row, indices []int, id int) []int {
+	out := indices[:0]
+	for _, i := range indices {
+		if rows[i].id != id {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// toggle adds id to the selection, or removes it if already selected.
+func toggle(selected []int, id int) []int {
+	for _, s := range selected {
+		if s == id {
+			return removeValue(selected, id)
 		}
 	}
+	return append(selected, id)
+}
+
+func removeValue(values []int, v int) []int {
+	out := make([]int, 0, len(values))
+	for _, x := range values {
+		if x != v {
+			out = append(out, x)
+		}
+	}
+	return out
 }
 
 func moveUp(cursor, scroll *int) {
@@ -175,6 +502,12 @@ func eqCaseInsensitive(a, b byte) bool {
 	return a == b
 }
 
+// isRemoveKey reports whether key is Delete or Backspace, which no key
+// scheme uses to navigate.
+func isRemoveKey(key []byte) bool {
+	return key[0] == 127 || key[0] == 8 || string(key) == "\x1b[3~"
+}
+
 func isQuitKey(b byte, opts Options) bool {
 	if b == 3 { // Ctrl+C
 		return true
@@ -210,7 +543,7 @@ func helpLine(opts Options) string {
 	m := i18n.Get()
 	switch opts.KeyScheme {
 	case config.KeySchemeWASD:
-		return fmt.Sprintf("%s  %s%s", ansi.Gray, m.HelpWASD, ansi.Reset)
+		return m.HelpWASD
 	case config.KeySchemeCustom:
 		up := KeyDisplayName(opts.CustomUpKey)
 		down := KeyDisplayName(opts.CustomDownKey)
@@ -218,13 +551,13 @@ func helpLine(opts Options) string {
 		if opts.CustomUpKey == 'q' || opts.CustomDownKey == 'q' {
 			quitHint = "Ctrl+C quit"
 		}
-		return fmt.Sprintf("%s  %s%s", ansi.Gray, fmt.Sprintf(m.HelpCustomFmt, strings.ToLower(up), strings.ToLower(down), quitHint), ansi.Reset)
+		return fmt.Sprintf(m.HelpCustomFmt, strings.ToLower(up), strings.ToLower(down), quitHint)
 	default:
-		return fmt.Sprintf("%s  %s%s", ansi.Gray, m.HelpArrows, ansi.Reset)
+		return m.HelpArrows
 	}
 }
 
-func renderMenu(scripts []parser.Script, cursor, scroll, maxVisible int, filter string, filtering bool, prevLines int, opts Options) int {
+func renderMenu(spec menuSpec, filtered []int, highlights map[int][]int, toggled []int, cursor, scroll, maxVisible int, filter string, filtering bool, prompt string, prevLines int, opts Options) int {
 	for i := 0; i < prevLines; i++ {
 		fmt.Print(ansi.Up + ansi.ClearLine)
 	}
@@ -236,85 +569,196 @@ func renderMenu(scripts []parser.Script, cursor, scroll, maxVisible int, filter
 	}
 
 	msg := i18n.Get()
-	printLine(fmt.Sprintf("%s%s%s%s", ansi.Bold, ansi.Purple, msg.MenuTitle, ansi.Reset))
+	printLine(fmt.Sprintf("%s%s%s%s", ansi.Bold, ansi.Purple, spec.title, ansi.Reset))
 
-	if filtering {
+	if prompt != "" {
+		printLine(fmt.Sprintf("%s  %s%s", ansi.Yellow, prompt, ansi.Reset))
+	} else if filtering {
 		printLine(fmt.Sprintf("%s  %s%s%s█%s", ansi.Gray, msg.FilterLabel, ansi.Reset, filter, ansi.Reset))
 	} else if filter != "" {
 		printLine(fmt.Sprintf("%s  %s%s%s%s", ansi.Gray, msg.FilterActiveLabel, ansi.Reset, filter, ansi.Reset))
 	} else {
-		printLine(helpLine(opts))
+		help := helpLine(opts)
+		if spec.hint != "" {
+			help += "  •  " + spec.hint
+		}
+		printLine(fmt.Sprintf("%s  %s%s", ansi.Gray, help, ansi.Reset))
 	}
 	printLine("")
 
-	if len(scripts) == 0 {
-		printLine(fmt.Sprintf("%s  %s%s", ansi.Gray, msg.NoMatchingScripts, ansi.Reset))
+	if len(filtered) == 0 {
+		printLine(fmt.Sprintf("%s  %s%s", ansi.Gray, spec.empty, ansi.Reset))
 	} else {
 		end := scroll + maxVisible
-		if end > len(scripts) {
-			end = len(scripts)
+		if end > len(filtered) {
+			end = len(filtered)
 		}
 
 		// Calculate max name width for alignment
 		maxNameLen := 0
 		for i := scroll; i < end; i++ {
-			if len(scripts[i].Name) > maxNameLen {
-				maxNameLen = len(scripts[i].Name)
+			if l := len([]rune(spec.rows[filtered[i]].label(filter == ""))); l > maxNameLen {
+				maxNameLen = l
 			}
 		}
 		if maxNameLen < 20 {
 			maxNameLen = 20
 		}
-
-		fmtStr := fmt.Sprintf("%%-%ds", maxNameLen)
+		badgeLen := 0
+		for i := scroll; i < end; i++ {
+			badgeLen = max(badgeLen, len(spec.rows[filtered[i]].badge))
+		}
 
 		for i := scroll; i < end; i++ {
-			s := scripts[i]
+			r := spec.rows[filtered[i]]
+			// Sections follow the list order, which filtering replaces by relevance
+			if filter == "" && r.section != "" && (i == scroll || spec.rows[filtered[i-1]].section != r.section) {
+				printLine(fmt.Sprintf("%s  %s%s", ansi.Gray, r.section, ansi.Reset))
+			}
+			prefix := ""
+			if len(toggled) > 0 {
+				prefix = "   "
+				for n, id := range toggled {
+					if id == r.id {
+						prefix = fmt.Sprintf("%s%-3s%s", ansi.Green, fmt.Sprintf("✓%d", n+1), ansi.Reset)
+						break
+					}
+				}
+				prefix += " "
+			}
+			if r.prefix != "" {
+				prefix += r.prefix + "  "
+			}
+
+			// Matched characters stand out in the palette color opposite the row's
+			c, hl := "", ansi.Purple
+			if len(opts.ColorPalette) > 0 {
+				c = opts.ColorPalette[i%len(opts.ColorPalette)]
+				hl = opts.ColorPalette[(i+len(opts.ColorPalette)/2)%len(opts.ColorPalette)]
+			}
 			var line string
 			if i == cursor {
-				c := ansi.Purple
-				if len(opts.ColorPalette) > 0 {
-					c = opts.ColorPalette[i%len(opts.ColorPalette)]
+				if c == "" {
+					c = ansi.Purple
 				}
-				line = fmt.Sprintf("  %s%s▶ %s%s"+fmtStr+"%s", ansi.Bold, ansi.Purple, ansi.Bold, c, s.Name, ansi.Reset)
-			} else if len(opts.ColorPalette) > 0 {
-				c := opts.ColorPalette[i%len(opts.ColorPalette)]
-				line = fmt.Sprintf("    %s"+fmtStr+"%s", c, s.Name, ansi.Reset)
+				name := highlightName(r.label(filter == ""), maxNameLen, highlights[filtered[i]], ansi.Bold+c, hl)
+				line = fmt.Sprintf("  %s%s▶ %s%s%s", ansi.Bold, ansi.Purple, ansi.Reset, prefix, name)
 			} else {
-				line = fmt.Sprintf("    "+fmtStr, s.Name)
+				line = "    " + prefix + highlightName(r.label(filter == ""), maxNameLen, highlights[filtered[i]], c, hl)
 			}
 
-			// Show description (from x-skit) or command as description
-			desc := s.Description
-			if desc == "" {
-				desc = s.Command
+			if badgeLen > 0 {
+				line += fmt.Sprintf("  %s%-*s%s", ansi.Blue, badgeLen, r.badge, ansi.Reset)
 			}
-			line += fmt.Sprintf("  %s%s%s", ansi.Gray, desc, ansi.Reset)
+			line += fmt.Sprintf("  %s%s%s", ansi.Gray, r.detail, ansi.Reset)
 
 			printLine(line)
+			if i == cursor {
+				for _, e := range r.expand {
+					printLine(fmt.Sprintf("      %s%s%s", ansi.Gray, e, ansi.Reset))
+				}
+			}
 		}
-		if len(scripts) > maxVisible {
-			printLine(fmt.Sprintf("%s  "+msg.ScriptCount+"%s", ansi.Gray, cursor+1, len(scripts), ansi.Reset))
+		if len(filtered) > maxVisible {
+			printLine(fmt.Sprintf("%s  "+spec.countFmt+"%s", ansi.Gray, cursor+1, len(filtered), ansi.Reset))
 		}
 	}
 
 	return lines
 }
 
-func applyFilter(scripts []parser.Script, filter string) []parser.Script {
-	if filter == "" {
-		return scripts
+// applyFilter returns the indices of the rows fuzzy-matching filter, best
+// match first, and the rune positions to highlight in each matched name. An
+// empty filter lists every row but the hidden ones.
+// Every space-separated word of the filter must match one of the search
+// fields; matches on the name (the first search field) outrank matches on
+// the other fields.
+func applyFilter(rows []row, indices []int, filter string) ([]int, map[int][]int) {
+	words := strings.Fields(filter)
+	if len(words) == 0 {
+		var listed []int
+		for _, i := range indices {
+			if !rows[i].hidden || rows[i].pinned {
+				listed = append(listed, i)
+			}
+		}
+		return listed, nil
 	}
-	f := strings.ToLower(filter)
-	var result []parser.Script
-	for _, s := range scripts {
-		if strings.Contains(strings.ToLower(s.Name), f) ||
-			strings.Contains(strings.ToLower(s.Command), f) ||
-			strings.Contains(strings.ToLower(s.Description), f) {
-			result = append(result, s)
+
+	type scored struct {
+		idx   int
+		score int
+	}
+	var matches []scored
+	highlights := make(map[int][]int)
+	for _, i := range indices {
+		r := rows[i]
+		if r.pinned {
+			continue
+		}
+		total, all := 0, true
+		var positions []int
+		for _, word := range words {
+			best, matched := 0, false
+			for f, field := range r.search {
+				score, pos, ok := fuzzyMatch(word, field)
+				if !ok {
+					continue
+				}
+				if f == 0 {
+					positions = append(positions, pos...)
+				} else {
+					score /= 2
+				}
+				if !matched || score > best {
+					best, matched = score, true
+				}
+			}
+			if !matched {
+				all = false
+				break
+			}
+			total += best
+		}
+		if all {
+			matches = append(matches, scored{i, total})
+			if len(positions) > 0 {
+				sort.Ints(positions)
+				highlights[i] = slices.Compact(positions)
+			}
 		}
 	}
-	return result
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].score > matches[b].score
+	})
+	result := make([]int, len(matches))
+	for k, mt := range matches {
+		result[k] = mt.idx
+	}
+	return result, highlights
+}
+
+// highlightName pads name to width and renders it in base, with the runes at
+// positions emphasized in hl.
+func highlightName(name string, width int, positions []int, base, hl string) string {
+	var b strings.Builder
+	b.WriteString(base)
+	runes := []rune(name)
+	next := 0
+	for i, r := range runes {
+		if next < len(positions) && positions[next] == i {
+			b.WriteString(ansi.Reset + ansi.Bold + ansi.Underline + hl + string(r) + ansi.Reset + base)
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	if pad := width - len(runes); pad > 0 {
+		b.WriteString(strings.Repeat(" ", pad))
+	}
+	b.WriteString(ansi.Reset)
+	return b.String()
 }
 
 func clearLines(n int) {
@@ -323,11 +767,18 @@ func clearLines(n int) {
 	}
 }
 
-func runFallbackMenu(scripts []parser.Script, palette []string) SelectionResult {
+func runFallbackMenu(spec menuSpec, palette []string) ([]int, bool) {
 	m := i18n.Get()
 	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Purple, m.FallbackTitle, ansi.Reset)
-	for i, s := range scripts {
+	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Purple, spec.fallbackTitle, ansi.Reset)
+	var listed []int
+	for i, r := range spec.rows {
+		if !r.pinned && !r.hidden {
+			listed = append(listed, i)
+		}
+	}
+	for i, idx := range listed {
+		r := spec.rows[idx]
 		numColor := ansi.Purple
 		nameColor := ""
 		nameReset := ""
@@ -337,25 +788,42 @@ func runFallbackMenu(scripts []parser.Script, palette []string) SelectionResult
 			nameColor = c
 			nameReset = ansi.Reset
 		}
-		desc := s.Description
-		if desc == "" {
-			desc = s.Command
+		prefix := ""
+		if r.prefix != "" {
+			prefix = r.prefix + " "
+		}
+		badge := ""
+		if r.badge != "" {
+			badge = fmt.Sprintf("%s%-4s%s ", ansi.Blue, r.badge, ansi.Reset)
 		}
-		fmt.Printf("  %s%2d.%s %s%-30s%s %s%s%s\n", numColor, i+1, ansi.Reset, nameColor, s.Name, nameReset, ansi.Gray, desc, ansi.Reset)
+		fmt.Printf("  %s%2d.%s %s%s%-30s%s %s%s%s%s\n", numColor, i+1, ansi.Reset, prefix, nameColor, r.label(false), nameReset, badge, ansi.Gray, r.detail, ansi.Reset)
 	}
-	fmt.Printf("\n%s%s%s", ansi.Gray, m.FallbackPrompt, ansi.Reset)
+	fmt.Printf("\n%s%s%s", ansi.Gray, spec.fallbackPrompt, ansi.Reset)
 
 	for {
-		input, _ := reader.ReadString('\n')
+		input, err := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
-		if input == "q" {
-			return SelectionResult{}
+		if input == "q" || (err != nil && input == "") {
+			return nil, false
+		}
+
+		// Multi-select menus accept several numbers: "1 3 4" or "1,3,4"
+		fields := []string{input}
+		if spec.multi {
+			fields = strings.FieldsFunc(input, func(r rune) bool { return r == ' ' || r == ',' })
+		}
+		var ids []int
+		for _, f := range fields {
+			var idx int
+			if _, err := fmt.Sscanf(f, "%d", &idx); err != nil || idx < 1 || idx > len(listed) {
+				ids = nil
+				break
+			}
+			ids = append(ids, spec.rows[listed[idx-1]].id)
 		}
-		var idx int
-		if _, err := fmt.Sscanf(input, "%d", &idx); err == nil && idx >= 1 && idx <= len(scripts) {
-			s := scripts[idx-1]
-			return SelectionResult{Script: &s, Confirmed: true}
+		if len(ids) > 0 {
+			return ids, true
 		}
-		fmt.Printf("%s"+m.FallbackInvalid+"%s", ansi.Red, len(scripts), ansi.Reset)
+		fmt.Printf("%s"+m.FallbackInvalid+"%s", ansi.Red, len(listed), ansi.Reset)
 	}
 }
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/subut0n/skit/internal/parser"
+)
+
+func TestApplyFilterMatchesEveryWord(t *testing.T) {
+	rows := []row{
+		scriptRow(0, parser.Script{Name: "build", Command: "next build", Workspace: "@acme/web"}),
+		scriptRow(1, parser.Script{Name: "build", Command: "tsc", Workspace: "@acme/ui"}),
+		scriptRow(2, parser.Script{Name: "dev", Command: "next dev", Workspace: "@acme/web"}),
+	}
+
+	got, highlights := applyFilter(rows, []int{0, 1, 2}, "web build")
+	if !slices.Equal(got, []int{0}) {
+		t.Fatalf("applyFilter(\"web build\") = %v, want [0]", got)
+	}
+	// "@acme/web › build": both words are highlighted in the full label
+	label := []rune(rows[0].label(false))
+	var matched string
+	for _, p := range highlights[0] {
+		matched += string(label[p])
+	}
+	if matched != "webbuild" {
+		t.Errorf("highlighted %q, want \"webbuild\"", matched)
+	}
+
+	if got, _ := applyFilter(rows, []int{0, 1, 2}, "  "); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("blank filter = %v, want every row", got)
+	}
+}
+
+func TestApplyFilterHidesHooksUntilFiltering(t *testing.T) {
+	rows := []row{
+		scriptRow(0, parser.Script{Name: "build", Command: "vite build", Pre: "rimraf dist", Post: "size-limit"}),
+		scriptRow(1, parser.Script{Name: "postbuild", Command: "size-limit", HookOf: "build"}),
+		scriptRow(2, parser.Script{Name: "prebuild", Command: "rimraf dist", HookOf: "build"}),
+	}
+	if d := rows[0].detail; d != "vite build  ↺ pre/post" {
+		t.Errorf("build detail = %q", d)
+	}
+	if len(rows[0].expand) != 2 {
+		t.Errorf("build expand = %q, want both hook commands", rows[0].expand)
+	}
+
+	if got, _ := applyFilter(rows, []int{0, 1, 2}, ""); !slices.Equal(got, []int{0}) {
+		t.Errorf("empty filter = %v, want [0]", got)
+	}
+	if got, _ := applyFilter(rows, []int{0, 1, 2}, "prebuild"); !slices.Contains(got, 2) {
+		t.Errorf("applyFilter(\"prebuild\") = %v, want the hook listed", got)
+	}
+}
+
+func TestScriptRowXSkit(t *testing.T) {
+	rows := []row{
+		scriptRow(0, parser.Script{Name: "deploy", Command: "./deploy.sh", Tags: []string{"ops"}, Aliases: []string{"ship"}}),
+		scriptRow(1, parser.Script{Name: "seed", Command: "node seed.js", Hidden: true}),
+	}
+	if d := rows[0].detail; d != "./deploy.sh  #ops" {
+		t.Errorf("deploy detail = %q", d)
+	}
+	if got, _ := applyFilter(rows, []int{0, 1}, ""); !slices.Equal(got, []int{0}) {
+		t.Errorf("empty filter = %v, want [0]", got)
+	}
+	if got, _ := applyFilter(rows, []int{0, 1}, "ship"); !slices.Equal(got, []int{0}) {
+		t.Errorf("applyFilter(\"ship\") = %v, want the script with that alias", got)
+	}
+	if got, _ := applyFilter(rows, []int{0, 1}, "seed"); !slices.Equal(got, []int{1}) {
+		t.Errorf("applyFilter(\"seed\") = %v, want the hidden script", got)
+	}
+}
@@ -22,6 +22,13 @@ type termState struct {
 	t termios
 }
 
+// IsTerminal reports whether stdin is an interactive terminal.
+func IsTerminal() bool {
+	var t termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdin.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t)))
+	return errno == 0
+}
+
 func makeRaw() (*termState, error) {
 	fd := os.Stdin.Fd()
 	var t termios
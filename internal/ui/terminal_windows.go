@@ -6,6 +6,11 @@ import "fmt"
 
 type termState struct{}
 
+// IsTerminal reports whether stdin is an interactive terminal.
+func IsTerminal() bool {
+	return false
+}
+
 func makeRaw() (*termState, error) {
 	return nil, fmt.Errorf("raw mode not supported on Windows")
 }
@@ -0,0 +1,129 @@
+package workspace
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Select returns the packages matched by the given selectors, sorted by
+// index. Selectors follow pnpm's --filter syntax:
+//
+//	@acme/web      the package named @acme/web
+//	@acme/*        packages whose name matches the glob
+//	./apps/*       packages whose directory, relative to the root, matches the glob
+//	@acme/web...   the package and everything it depends on
+//	@acme/web^...  only what the package depends on
+//	...@acme/ui    the package and everything depending on it
+//	...^@acme/ui   only what depends on the package
+//	!@acme/legacy  excludes the matches of the rest of the selector
+//
+// Packages matched by any selector are kept, minus those matched by an
+// exclusion.
+func (g *Graph) Select(selectors []string) ([]int, error) {
+	included := make(map[int]bool)
+	excluded := make(map[int]bool)
+	for _, sel := range selectors {
+		set := included
+		if rest, ok := strings.CutPrefix(sel, "!"); ok {
+			sel, set = rest, excluded
+		}
+		matches, err := g.selectOne(sel)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range matches {
+			set[i] = true
+		}
+	}
+	for i := range excluded {
+		delete(included, i)
+	}
+	return sortedKeys(included), nil
+}
+
+// selectOne resolves a single selector without its exclusion prefix.
+func (g *Graph) selectOne(sel string) ([]int, error) {
+	pattern := sel
+	withDeps, withDependents, withSelf := false, false, true
+	if rest, ok := strings.CutSuffix(pattern, "..."); ok {
+		withDeps, pattern = true, rest
+		if rest, ok := strings.CutSuffix(pattern, "^"); ok {
+			withSelf, pattern = false, rest
+		}
+	}
+	if rest, ok := strings.CutPrefix(pattern, "..."); ok {
+		withDependents, pattern = true, rest
+		if rest, ok := strings.CutPrefix(pattern, "^"); ok {
+			withSelf, pattern = false, rest
+		}
+	}
+	if pattern == "" {
+		return nil, fmt.Errorf("invalid filter %q", sel)
+	}
+
+	var matched []int
+	for i, p := range g.Packages {
+		ok, err := matchPackage(pattern, p.Name, p.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", sel, err)
+		}
+		if ok {
+			matched = append(matched, i)
+		}
+	}
+
+	selected := make(map[int]bool)
+	for _, i := range matched {
+		if withSelf {
+			selected[i] = true
+		}
+		if withDeps {
+			for _, d := range g.Dependencies(i) {
+				selected[d] = true
+			}
+		}
+		if withDependents {
+			for _, d := range g.Dependents(i) {
+				selected[d] = true
+			}
+		}
+	}
+	return sortedKeys(selected), nil
+}
+
+// matchPackage reports whether a package matches a name or path pattern.
+// Patterns starting with "." are matched against the package directory.
+func matchPackage(pattern, name, dir string) (bool, error) {
+	if strings.HasPrefix(pattern, ".") {
+		return path.Match(path.Clean(pattern), path.Clean(filepath.ToSlash(dir)))
+	}
+	return path.Match(pattern, name)
+}
+
+// Affected returns the packages containing any of the given files, plus
+// every package depending on them, sorted by index. Files are relative to the
+// monorepo root; a file belongs to the package with the deepest directory
+// containing it, and files outside every package are ignored.
+func (g *Graph) Affected(files []string) []int {
+	selected := make(map[int]bool)
+	for _, file := range files {
+		file = path.Clean(filepath.ToSlash(file))
+		owner, depth := -1, -1
+		for i, p := range g.Packages {
+			dir := path.Clean(filepath.ToSlash(p.Path))
+			if (file == dir || strings.HasPrefix(file, dir+"/")) && len(dir) > depth {
+				owner, depth = i, len(dir)
+			}
+		}
+		if owner < 0 || selected[owner] {
+			continue
+		}
+		selected[owner] = true
+		for _, d := range g.Dependents(owner) {
+			selected[d] = true
+		}
+	}
+	return sortedKeys(selected)
+}
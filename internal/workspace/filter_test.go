@@ -0,0 +1,87 @@
+package workspace
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/subut0n/skit/internal/parser"
+)
+
+func filterGraph() *Graph {
+	return NewGraph([]parser.WorkspaceInfo{
+		{Name: "@acme/api", Path: "apps/api", Dependencies: []string{"@acme/db"}},
+		{Name: "@acme/web", Path: "apps/web", Dependencies: []string{"@acme/ui", "react"}},
+		{Name: "@acme/db", Path: "packages/db", Dependencies: []string{"@acme/utils"}},
+		{Name: "@acme/ui", Path: "packages/ui", Dependencies: []string{"@acme/utils"}},
+		{Name: "@acme/utils", Path: "packages/utils"},
+		{Name: "docs", Path: "docs"},
+	})
+}
+
+func TestSelect(t *testing.T) {
+	g := filterGraph()
+	tests := []struct {
+		selectors []string
+		want      []string
+	}{
+		{[]string{"@acme/web"}, []string{"@acme/web"}},
+		{[]string{"@acme/web..."}, []string{"@acme/web", "@acme/ui", "@acme/utils"}},
+		{[]string{"@acme/web^..."}, []string{"@acme/ui", "@acme/utils"}},
+		{[]string{"...@acme/ui"}, []string{"@acme/web", "@acme/ui"}},
+		{[]string{"...@acme/utils"}, []string{"@acme/api", "@acme/web", "@acme/db", "@acme/ui", "@acme/utils"}},
+		{[]string{"...^@acme/db"}, []string{"@acme/api"}},
+		{[]string{"./apps/*"}, []string{"@acme/api", "@acme/web"}},
+		{[]string{"apps/*"}, nil},
+		{[]string{"@acme/*", "!./packages/*"}, []string{"@acme/api", "@acme/web"}},
+		{[]string{"docs", "@acme/api"}, []string{"@acme/api", "docs"}},
+		{[]string{"./apps/*...", "!@acme/utils"}, []string{"@acme/api", "@acme/web", "@acme/db", "@acme/ui"}},
+		{[]string{"missing"}, nil},
+	}
+	for _, tt := range tests {
+		got, err := g.Select(tt.selectors)
+		if err != nil {
+			t.Errorf("Select(%q) error: %v", tt.selectors, err)
+			continue
+		}
+		if names := names(g, got); !slices.Equal(names, tt.want) {
+			t.Errorf("Select(%q) = %v, want %v", tt.selectors, names, tt.want)
+		}
+	}
+}
+
+func TestSelectInvalid(t *testing.T) {
+	g := filterGraph()
+	for _, sel := range []string{"...", "!", "[a-"} {
+		if _, err := g.Select([]string{sel}); err == nil {
+			t.Errorf("Select(%q) should fail", sel)
+		}
+	}
+}
+
+func TestAffected(t *testing.T) {
+	g := filterGraph()
+	tests := []struct {
+		files []string
+		want  []string
+	}{
+		{[]string{"packages/ui/src/button.tsx"}, []string{"@acme/web", "@acme/ui"}},
+		{[]string{"packages/utils/index.ts"}, []string{"@acme/api", "@acme/web", "@acme/db", "@acme/ui", "@acme/utils"}},
+		{[]string{"apps/api/main.ts", "docs/intro.md"}, []string{"@acme/api", "docs"}},
+		{[]string{"package.json", "apps/webapp/x", "README.md"}, nil},
+	}
+	for _, tt := range tests {
+		if got := names(g, g.Affected(tt.files)); !slices.Equal(got, tt.want) {
+			t.Errorf("Affected(%q) = %v, want %v", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestAffectedNestedPackages(t *testing.T) {
+	g := NewGraph([]parser.WorkspaceInfo{
+		{Name: "outer", Path: "packages/outer"},
+		{Name: "inner", Path: "packages/outer/inner"},
+	})
+	if got := names(g, g.Affected([]string{"packages/outer/inner/x.ts"})); !slices.Equal(got, []string{"inner"}) {
+		t.Errorf("Affected = %v, want [inner]", got)
+	}
+}
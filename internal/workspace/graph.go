@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"sort"
+
+	"github.com/subut0n/skit/internal/parser"
+)
+
+// Graph links the packages of a monorepo through the dependencies they
+// declare on each other. Packages are referred to by their index in Packages.
+type Graph struct {
+	Packages []parser.WorkspaceInfo
+
+	byName     map[string]int
+	deps       [][]int // direct dependencies of each package, sorted
+	dependents [][]int // packages depending directly on each package, sorted
+}
+
+// NewGraph builds the graph of the given packages. Only dependencies naming
+// another package of the list are kept.
+func NewGraph(packages []parser.WorkspaceInfo) *Graph {
+	g := &Graph{
+		Packages:   packages,
+		byName:     make(map[string]int, len(packages)),
+		deps:       make([][]int, len(packages)),
+		dependents: make([][]int, len(packages)),
+	}
+	for i, p := range packages {
+		g.byName[p.Name] = i
+	}
+	for i, p := range packages {
+		for _, name := range p.Dependencies {
+			if j, ok := g.byName[name]; ok && j != i {
+				g.deps[i] = append(g.deps[i], j)
+			}
+		}
+		sort.Ints(g.deps[i])
+	}
+	for i, deps := range g.deps {
+		for _, d := range deps {
+			g.dependents[d] = append(g.dependents[d], i)
+		}
+	}
+	return g
+}
+
+// Index returns the index of the package with the given name.
+func (g *Graph) Index(name string) (int, bool) {
+	i, ok := g.byName[name]
+	return i, ok
+}
+
+// Dependencies returns every package i depends on, directly or through other
+// packages, sorted by index.
+func (g *Graph) Dependencies(i int) []int {
+	seen := reach(g.deps, i)
+	delete(seen, i)
+	return sortedKeys(seen)
+}
+
+// Dependents returns every package depending on i, directly or through other
+// packages, sorted by index.
+func (g *Graph) Dependents(i int) []int {
+	seen := reach(g.dependents, i)
+	delete(seen, i)
+	return sortedKeys(seen)
+}
+
+// reach returns the packages reachable from i through edges. It contains i
+// only when i is part of a cycle.
+func reach(edges [][]int, i int) map[int]bool {
+	seen := make(map[int]bool)
+	var walk func(int)
+	walk = func(j int) {
+		for _, d := range edges[j] {
+			if !seen[d] {
+				seen[d] = true
+				walk(d)
+			}
+		}
+	}
+	walk(i)
+	return seen
+}
+
+// Order sorts the given packages so that each comes after the packages it
+// depends on, directly or not, and otherwise as early as its position in
+// indexes allows. When only dependency cycles are left, the first package of
+// the list that is part of one is placed as if its cycle did not exist.
+func (g *Graph) Order(indexes []int) []int {
+	pending := make(map[int]bool, len(indexes))
+	for _, i := range indexes {
+		pending[i] = true
+	}
+	waitsFor := make(map[int][]int, len(indexes))
+	for _, i := range indexes {
+		for _, d := range g.Dependencies(i) {
+			if pending[d] {
+				waitsFor[i] = append(waitsFor[i], d)
+			}
+		}
+	}
+
+	ordered := make([]int, 0, len(indexes))
+	placed := make(map[int]bool, len(indexes))
+	ready := func(i int) bool {
+		for _, d := range waitsFor[i] {
+			if !placed[d] {
+				return false
+			}
+		}
+		return true
+	}
+	for len(ordered) < len(indexes) {
+		// Place the first package of the list whose dependencies are placed.
+		progress := false
+		for _, i := range indexes {
+			if !placed[i] && ready(i) {
+				placed[i] = true
+				ordered = append(ordered, i)
+				progress = true
+				break
+			}
+		}
+		if progress {
+			continue
+		}
+		// Everything left waits on a cycle: release a package of the cycle.
+		for _, i := range indexes {
+			if !placed[i] && reach(g.deps, i)[i] {
+				placed[i] = true
+				ordered = append(ordered, i)
+				break
+			}
+		}
+	}
+	return ordered
+}
+
+func sortedKeys(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package workspace
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/subut0n/skit/internal/parser"
+)
+
+// testGraph builds a graph of packages named order, depending on defs[name].
+func testGraph(defs map[string][]string, order ...string) *Graph {
+	var packages []parser.WorkspaceInfo
+	for _, name := range order {
+		packages = append(packages, parser.WorkspaceInfo{Name: name, Path: "packages/" + name, Dependencies: defs[name]})
+	}
+	return NewGraph(packages)
+}
+
+func names(g *Graph, indexes []int) []string {
+	var out []string
+	for _, i := range indexes {
+		out = append(out, g.Packages[i].Name)
+	}
+	return out
+}
+
+func TestDependenciesAreTransitiveAndInternal(t *testing.T) {
+	g := testGraph(map[string][]string{
+		"web":   {"ui", "react"},
+		"ui":    {"utils"},
+		"utils": {"lodash"},
+	}, "ui", "utils", "web")
+
+	web, _ := g.Index("web")
+	if got := names(g, g.Dependencies(web)); !slices.Equal(got, []string{"ui", "utils"}) {
+		t.Errorf("Dependencies(web) = %v, want [ui utils]", got)
+	}
+	utils, _ := g.Index("utils")
+	if got := g.Dependencies(utils); len(got) != 0 {
+		t.Errorf("Dependencies(utils) = %v, want none", names(g, got))
+	}
+}
+
+func TestOrderPutsDependenciesFirst(t *testing.T) {
+	g := testGraph(map[string][]string{
+		"api": {"db"},
+		"web": {"ui"},
+		"ui":  {"utils"},
+		"db":  {"utils"},
+	}, "api", "db", "ui", "utils", "web")
+
+	got := names(g, g.Order([]int{0, 1, 2, 3, 4}))
+	want := []string{"utils", "db", "api", "ui", "web"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Order = %v, want %v", got, want)
+	}
+}
+
+func TestOrderFollowsPackagesOutsideTheSelection(t *testing.T) {
+	// web depends on utils only through ui, which is not selected.
+	g := testGraph(map[string][]string{
+		"web": {"ui"},
+		"ui":  {"utils"},
+	}, "ui", "utils", "web")
+
+	web, _ := g.Index("web")
+	utils, _ := g.Index("utils")
+	got := names(g, g.Order([]int{web, utils}))
+	if !slices.Equal(got, []string{"utils", "web"}) {
+		t.Errorf("Order = %v, want [utils web]", got)
+	}
+}
+
+func TestOrderBreaksCycles(t *testing.T) {
+	g := testGraph(map[string][]string{
+		"a":   {"b"},
+		"b":   {"a"},
+		"app": {"a"},
+	}, "app", "a", "b")
+
+	got := names(g, g.Order([]int{0, 1, 2}))
+	if len(got) != 3 || got[2] != "app" {
+		t.Errorf("Order = %v, want the cycle first and app last", got)
+	}
+}
@@ -1,13 +1,13 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/subut0n/skit/internal/ansi"
 	"github.com/subut0n/skit/internal/config"
@@ -16,6 +16,7 @@ import (
 	"github.com/subut0n/skit/internal/i18n"
 	"github.com/subut0n/skit/internal/parser"
 	"github.com/subut0n/skit/internal/ui"
+	"github.com/subut0n/skit/internal/workspace"
 )
 
 // Version is set via -ldflags "-X main.Version=x.y.z"
@@ -52,24 +53,120 @@ func getPalette(scheme config.ColorScheme) []string {
 	}
 }
 
-func main() {
-	// Parse flags: extract --root and -w/--workspace before the switch
-	useRoot := false
-	useWorkspace := false
-	var filteredArgs []string
-	for _, arg := range os.Args[1:] {
+// cliArgs holds the parsed command line.
+type cliArgs struct {
+	useRoot      bool
+	useWorkspace bool
+	monorepo     bool // one menu with the scripts of every workspace package
+	parallel     bool
+	limit        int // maximum number of scripts running at once in parallel mode, 0 for no limit
+	killOthers   bool
+	noHooks      bool     // skip the pre and post hooks of package.json scripts
+	all          bool     // run in every workspace package
+	filters      []string // workspace package selectors from --filter
+	changed      bool     // --changed: only packages changed since changedSince
+	changedSince string   // git ref given to --changed, empty for the default branch
+	args         []string // skit's own arguments, starting with the command or script name
+	extra        []string // arguments forwarded to the script
+}
+
+// parseArgs splits the command line into skit flags and arguments forwarded
+// to the script. Everything after the script name, or after a bare "--", is
+// forwarded untouched. In parallel mode every positional argument is a script
+// name, so only a bare "--" starts the forwarded arguments; a number right
+// after --parallel limits how many scripts run at once.
+func parseArgs(argv []string) cliArgs {
+	var c cliArgs
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
 		switch arg {
+		case "--":
+			c.extra = argv[i+1:]
+			return c
 		case "--root":
-			useRoot = true
+			c.useRoot = true
 		case "-w", "--workspace":
-			useWorkspace = true
+			c.useWorkspace = true
+		case "-m", "--monorepo":
+			c.monorepo = true
+		case "-p", "--parallel":
+			c.parallel = true
+			if i+1 < len(argv) {
+				if n, err := strconv.Atoi(argv[i+1]); err == nil && n > 0 {
+					c.limit = n
+					i++
+				}
+			}
+		case "!!":
+			// Same as --again: an entry number may follow.
+			c.args = append(c.args, arg)
+		case "--kill-others-on-fail":
+			c.killOthers = true
+		case "--no-hooks":
+			c.noHooks = true
+		case "--all":
+			c.all = true
+		case "--changed":
+			c.changed = true
+		case "--filter":
+			if i+1 < len(argv) {
+				i++
+				c.filters = append(c.filters, argv[i])
+			}
 		default:
-			filteredArgs = append(filteredArgs, arg)
+			if sel, ok := strings.CutPrefix(arg, "--filter="); ok {
+				c.filters = append(c.filters, sel)
+				continue
+			}
+			if ref, ok := strings.CutPrefix(arg, "--changed="); ok && ref != "" {
+				c.changed, c.changedSince = true, ref
+				continue
+			}
+			c.args = append(c.args, arg)
+			if len(c.args) == 1 && !strings.HasPrefix(arg, "-") && !c.parallel {
+				rest := argv[i+1:]
+				if len(rest) > 0 && rest[0] == "--" {
+					rest = rest[1:]
+				}
+				c.extra = rest
+				return c
+			}
 		}
 	}
+	return c
+}
+
+// runMode returns how scripts selected together are run.
+func (c cliArgs) runMode(cfg *config.Manager) runMode {
+	return runMode{
+		parallel:   c.parallel,
+		limit:      c.limit,
+		killOthers: c.killOthers,
+		noHooks:    c.noHooks,
+		palette:    getPalette(cfg.Config.ColorScheme),
+	}
+}
+
+func main() {
+	cli := parseArgs(os.Args[1:])
+	useRoot, useWorkspace := cli.useRoot, cli.useWorkspace
+
+	if cli.monorepo && len(cli.args) == 0 {
+		cfg := loadConfigAndSetLang()
+		runMonorepoMenu(cli, cfg)
+		return
+	}
 
-	if len(filteredArgs) > 0 {
-		arg := filteredArgs[0]
+	// --all, --changed, or --filter with a script name: run in several
+	// workspace packages
+	if cli.all || cli.changed || (len(cli.filters) > 0 && len(cli.args) > 0 && !strings.HasPrefix(cli.args[0], "-")) {
+		cfg := loadConfigAndSetLang()
+		runAllWorkspaces(cli, cfg)
+		return
+	}
+
+	if len(cli.args) > 0 {
+		arg := cli.args[0]
 		switch arg {
 		case "--version", "-v":
 			loadConfigAndSetLang()
@@ -91,17 +188,28 @@ func main() {
 		case "--keys":
 			runKeysSetup()
 			return
+		case "--order":
+			runOrderSetup()
+			return
+		case "--which":
+			loadConfigAndSetLang()
+			runWhich(useRoot)
+			return
 		case "--history", "-hist":
+			cfg := loadConfigAndSetLang()
+			showHistory(menuOptions(cfg))
+			return
+		case "!!", "--again":
 			loadConfigAndSetLang()
-			showHistory()
+			runAgain(cli.args[1:], cli.extra)
 			return
 		case "init", "config":
 			runConfigSetup()
 			return
 		default:
 			if !strings.HasPrefix(arg, "-") {
-				loadConfigAndSetLang()
-				runDirectScript(arg, useRoot)
+				cfg := loadConfigAndSetLang()
+				runDirectScript(cli.args, cli.extra, useRoot, cli.runMode(cfg))
 				return
 			}
 			cfg := loadConfigAndSetLang()
@@ -132,44 +240,32 @@ func main() {
 	}
 
 	// Resolve package.json path
-	pkgPath := resolvePackageJSON(useRoot, useWorkspace)
+	pkgPath := resolvePackageJSON(useRoot, useWorkspace || len(cli.filters) > 0, cli.filters, menuOptions(cfg))
 	if pkgPath == "" {
 		fatal("%s", i18n.Get().ErrNoPackageJSON)
 	}
 
 	m := i18n.Get()
 
-	scripts, err := parser.Parse(pkgPath)
+	target := newRunTarget(pkgPath)
+	scripts, err := parseScripts(pkgPath, target.pm)
 	if err != nil {
-		fatal(m.ErrReadPackageJSON, err)
+		fatal(m.ErrReadPackageJSON, filepath.Base(pkgPath), err)
 	}
 
 	if len(scripts) == 0 {
 		fatal("%s", m.ErrNoScripts)
 	}
 
-	// Detect package manager (look from the package.json dir, then walk up)
-	pkgDir := filepath.Dir(pkgPath)
-	pm := detector.Detect(pkgDir)
-	// If no lockfile in the package dir, try the root
-	if pm.Manager == detector.NPM && pkgDir != "" {
-		rootPkg := parser.FindRootPackageJSON(pkgDir)
-		if rootPkg != "" {
-			rootPM := detector.Detect(filepath.Dir(rootPkg))
-			if rootPM.Manager != detector.NPM {
-				pm = rootPM
-			}
-		}
-	}
-
 	// Display context line: relative path + package manager
-	printContext(pkgPath, pm)
+	printContext(pkgPath, target.pm)
 
-	opts := ui.Options{
-		KeyScheme:     cfg.Config.KeyScheme,
-		ColorPalette:  getPalette(cfg.Config.ColorScheme),
-		CustomUpKey:   cfg.Config.CustomUpKey,
-		CustomDownKey: cfg.Config.CustomDownKey,
+	opts := menuOptions(cfg)
+	if cfg.Config.ScriptOrder != config.ScriptOrderAlpha {
+		if hist, err := history.New(); err == nil {
+			opts.Recent = hist.Ranked(target.pkgPath, time.Now())
+			opts.Last = hist.LastScript(target.pkgPath)
+		}
 	}
 	result := ui.Run(scripts, opts)
 
@@ -178,15 +274,15 @@ func main() {
 		return
 	}
 
-	executeScript(result.Script.Name, result.Script.Command, pm)
+	os.Exit(runScripts(result.Scripts, cli.extra, target, cli.runMode(cfg)))
 }
 
 // resolvePackageJSON determines which package.json to use based on flags.
-func resolvePackageJSON(useRoot, useWorkspace bool) string {
+func resolvePackageJSON(useRoot, useWorkspace bool, filters []string, opts ui.Options) string {
 	m := i18n.Get()
 
 	if useWorkspace {
-		return resolveWorkspace()
+		return resolveWorkspace(filters, opts)
 	}
 
 	if useRoot {
@@ -204,8 +300,10 @@ func resolvePackageJSON(useRoot, useWorkspace bool) string {
 	return findPackageJSON()
 }
 
-// resolveWorkspace detects workspaces and lets the user pick one.
-func resolveWorkspace() string {
+// resolveWorkspace detects workspaces and lets the user pick one among those
+// matched by the filters, if any. The cursor starts on the package picked last
+// time in the same monorepo.
+func resolveWorkspace(filters []string, opts ui.Options) string {
 	m := i18n.Get()
 	dir, err := os.Getwd()
 	if err != nil {
@@ -222,88 +320,173 @@ func resolveWorkspace() string {
 		// No workspaces, fall back to root
 		return rootPkg
 	}
+	if len(filters) > 0 {
+		g := workspace.NewGraph(workspaces)
+		var selected []parser.WorkspaceInfo
+		for _, i := range selectPackages(g, filters) {
+			selected = append(selected, g.Packages[i])
+		}
+		if len(selected) == 1 {
+			return selected[0].PkgPath
+		}
+		workspaces = selected
+	}
 
-	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Purple, fmt.Sprintf(m.WorkspaceDetected, len(workspaces)), ansi.Reset)
+	state, _ := history.NewWorkspaces()
+	var last string
+	if state != nil {
+		last = state.Last(rootPkg)
+	}
 
+	items := make([]ui.Item, len(workspaces))
+	initial := 0
 	for i, ws := range workspaces {
-		fmt.Printf("  %s%2d.%s %s%-30s%s %s%s%s\n",
-			ansi.Purple, i+1, ansi.Reset,
-			ansi.Bold, ws.Name, ansi.Reset,
-			ansi.Gray, ws.Path, ansi.Reset,
-		)
+		scripts, _ := parser.Parse(ws.PkgPath)
+		count := m.WorkspaceScripts
+		if len(scripts) == 1 {
+			count = m.WorkspaceScript
+		}
+		items[i] = ui.Item{
+			Name:   ws.Name,
+			Detail: ws.Path + "  " + fmt.Sprintf(count, len(scripts)),
+			Search: []string{ws.Path},
+		}
+		if ws.PkgPath == last {
+			initial = i
+		}
 	}
 
-	fmt.Printf("\n%s%s%s", ansi.Gray, m.WorkspacePrompt, ansi.Reset)
+	idx, ok := ui.Select(ui.List{
+		Title:          fmt.Sprintf(m.WorkspaceDetected, len(workspaces)),
+		Items:          items,
+		CountFmt:       m.WorkspaceCountFmt,
+		Empty:          m.NoMatchingPackages,
+		FallbackPrompt: m.WorkspacePrompt,
+		Initial:        initial,
+	}, opts)
+	if !ok {
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
+		os.Exit(0)
+	}
 
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-		if input == "q" {
-			fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
-			os.Exit(0)
-		}
-		var idx int
-		if _, err := fmt.Sscanf(input, "%d", &idx); err == nil && idx >= 1 && idx <= len(workspaces) {
-			ws := workspaces[idx-1]
-			fmt.Println()
-			return ws.PkgPath
-		}
-		fmt.Printf("%s"+m.WorkspaceInvalid+"%s", ansi.Red, len(workspaces), ansi.Reset)
+	pkgPath := workspaces[idx].PkgPath
+	if state != nil {
+		_ = state.SetLast(rootPkg, pkgPath)
 	}
+	return pkgPath
 }
 
-// printContext displays the detected package.json path and package manager.
+// printContext displays the detected package.json path and package manager,
+// with a warning when files point to different package managers.
 func printContext(pkgPath string, pm detector.Info) {
 	m := i18n.Get()
 
-	displayPath := pkgPath
+	// Compact display: path ▸ runner
+	fmt.Printf("%s%s%s\n", ansi.Gray, fmt.Sprintf(m.ContextLine, displayPath(pkgPath), pm.Label()), ansi.Reset)
+	if conflicts := pm.Conflicts(); len(conflicts) > 0 {
+		var hints []string
+		for _, e := range conflicts {
+			hints = append(hints, fmt.Sprintf("%s (%s)", evidenceLabel(e), e.Name))
+		}
+		fmt.Printf("%s%s%s\n", ansi.Yellow, fmt.Sprintf(m.RunnerConflict, strings.Join(hints, ", ")), ansi.Reset)
+	}
+	fmt.Println()
+}
+
+// displayPath shortens a path for display: relative to the current
+// directory when below it, absolute with ~ for the home directory otherwise.
+func displayPath(path string) string {
+	display := path
 	cwd, err := os.Getwd()
 	if err == nil {
-		if rel, err := filepath.Rel(cwd, pkgPath); err == nil {
-			displayPath = rel
+		if rel, err := filepath.Rel(cwd, path); err == nil {
+			display = rel
 		}
 	}
 
 	// If relative path goes up (../), show absolute path with ~ instead
-	if strings.HasPrefix(displayPath, "..") {
-		abs, err := filepath.Abs(pkgPath)
+	if strings.HasPrefix(display, "..") {
+		abs, err := filepath.Abs(path)
 		if err == nil {
 			if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(abs, home) {
-				displayPath = "~" + abs[len(home):]
+				display = "~" + abs[len(home):]
 			} else {
-				displayPath = abs
+				display = abs
 			}
 		}
 	}
+	return display
+}
 
-	// Compact display: path ▸ runner
-	fmt.Printf("%s%s%s\n\n", ansi.Gray, fmt.Sprintf(m.ContextLine, displayPath, pm.Name), ansi.Reset)
+// evidenceLabel names a file pointing to a package manager, with the
+// package.json field when it is one.
+func evidenceLabel(e detector.Evidence) string {
+	if e.Field != "" {
+		return displayPath(e.Path) + " › " + e.Field
+	}
+	return displayPath(e.Path)
 }
 
-// executeScript runs a script via the detected package manager.
-func executeScript(name, command string, pm detector.Info) {
+// runWhich explains which package manager runs the scripts of the current
+// package.json, and why.
+func runWhich(useRoot bool) {
 	m := i18n.Get()
+	pkgPath := resolvePackageJSON(useRoot, false, nil, ui.Options{})
+	if pkgPath == "" {
+		fatal("%s", m.ErrNoPackageJSON)
+	}
+	pm := newRunTarget(pkgPath).pm
 
-	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.Executing, pm.RunCmd, name), ansi.Reset)
-
-	args := strings.Fields(pm.RunCmd)
-	args = append(args, name)
-	cmd := exec.Command(args[0], args[1:]...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-
-	hist, err := history.New()
-	if err == nil {
-		_ = hist.Add(name, command, pm.Name)
+	fmt.Printf("%s%s%s%s\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.WhichRunner, pm.Label(), pm.RunCmd), ansi.Reset)
+	fmt.Printf("%s%s%s\n", ansi.Gray, fmt.Sprintf(m.WhichPackage, displayPath(pkgPath)), ansi.Reset)
+	switch {
+	case pm.PnP:
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.WhichBerryPnP, ansi.Reset)
+	case pm.Manager == detector.YarnBerry:
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.WhichBerry, ansi.Reset)
 	}
+	fmt.Println()
 
-	if err := cmd.Run(); err != nil {
-		fatal("\n"+m.ErrCommandFailed, err)
+	switch {
+	case len(pm.Evidence) == 0:
+		fmt.Println(m.WhichDefault)
+	case pm.TaskRunner():
+		fmt.Printf(m.WhichSource+"\n", evidenceLabel(pm.Evidence[0]))
+	case pm.Declared():
+		fmt.Printf(m.WhichDeclared+"\n", evidenceLabel(pm.Evidence[0]))
+	default:
+		fmt.Printf(m.WhichLockfile+"\n", evidenceLabel(pm.Evidence[0]))
+	}
+	if !pm.TaskRunner() {
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.WhichPriority, ansi.Reset)
+	}
+	if len(pm.Evidence) == 0 {
+		return
 	}
 
-	fmt.Printf("\n%s%s%s%s\n", ansi.Bold, ansi.Green, m.Success, ansi.Reset)
+	fmt.Printf("\n%s\n", m.WhichEvidence)
+	width := 0
+	for _, e := range pm.Evidence {
+		width = max(width, utf8.RuneCountInString(evidenceLabel(e)))
+	}
+	for k, e := range pm.Evidence {
+		mark, color, note := "·", ansi.Gray, ""
+		switch {
+		case k == 0:
+			mark, color = "✓", ansi.Green
+		case e.Name != pm.Name:
+			mark, color, note = "✗", ansi.Yellow, "  "+m.WhichConflict
+		}
+		if e.Root {
+			note += "  " + m.WhichRoot
+		}
+		label := evidenceLabel(e)
+		name := e.Name
+		if e.Version != "" {
+			name += "@" + e.Version
+		}
+		fmt.Printf("  %s%s%s %s%s  %s%s%s%s\n", color, mark, ansi.Reset, label, strings.Repeat(" ", width-utf8.RuneCountInString(label)), name, ansi.Gray, note, ansi.Reset)
+	}
 }
 
 func printHelp(palette []string) {
@@ -314,15 +497,26 @@ func printHelp(palette []string) {
 	entries := []helpEntry{
 		{"skit", "Interactive menu"},
 		{"skit <script>", "Run a script directly"},
+		{"skit <script> -- <args>", "Forward arguments to the script"},
 		{"skit -w, --workspace", "Pick a workspace package"},
+		{"skit -m, --monorepo", "Scripts of every workspace in one menu"},
 		{"skit --root", "Use root package.json"},
+		{"skit -p <script>...", "Run in parallel (--kill-others-on-fail)"},
+		{"skit -w --all <script>", "Run in every workspace, dependencies first"},
+		{"skit --parallel <n>", "Run up to n at once (with --all)"},
+		{"skit --no-hooks <script>", "Skip the script's pre/post hooks"},
+		{"skit --filter <sel>", "Only packages matching sel (see README)"},
+		{"skit -w --changed[=ref]", "Only packages changed since ref (default branch)"},
+		{"skit --which", "Explain which runner is used, and why"},
 		{"skit --help, -h", "Show this help"},
 		{"skit --version, -v", "Show version"},
 		{"skit --config", "Configure language, colors and key scheme"},
 		{"skit --lang", "Change language"},
 		{"skit --colors", "Change color scheme"},
 		{"skit --keys", "Change key scheme"},
-		{"skit --history, -hist", "Show execution history"},
+		{"skit --order", "Recent-first or alphabetical menu"},
+		{"skit --history, -hist", "Browse history, re-run or delete entries"},
+		{"skit --again [n], !!", "Re-run the last script here (or history #n)"},
 	}
 
 	fmt.Printf("\n  %s%sskit%s %s— interactive script runner for package.json%s\n\n", ansi.Bold, ansi.Purple, ansi.Reset, ansi.Gray, ansi.Reset)
@@ -335,6 +529,16 @@ func printHelp(palette []string) {
 	fmt.Println()
 }
 
+// menuOptions builds the interactive menu options from the user configuration.
+func menuOptions(cfg *config.Manager) ui.Options {
+	return ui.Options{
+		KeyScheme:     cfg.Config.KeyScheme,
+		ColorPalette:  getPalette(cfg.Config.ColorScheme),
+		CustomUpKey:   cfg.Config.CustomUpKey,
+		CustomDownKey: cfg.Config.CustomDownKey,
+	}
+}
+
 func loadConfigAndSetLang() *config.Manager {
 	cfg, err := config.New()
 	if err != nil {
@@ -418,6 +622,16 @@ func runKeysSetup() {
 	})
 }
 
+func runOrderSetup() {
+	withConfig(func(cfg *config.Manager) {
+		order, err := config.RunOrderSetup()
+		if err != nil {
+			fatal(i18n.Get().ErrGeneric, err)
+		}
+		cfg.Config.ScriptOrder = order
+	})
+}
+
 func captureCustomKeys() (upKey, downKey byte) {
 	m := i18n.Get()
 	fmt.Println()
@@ -449,53 +663,138 @@ func captureCustomKeys() (upKey, downKey byte) {
 	}
 }
 
-func runDirectScript(script string, useRoot bool) {
-	pkgPath := resolvePackageJSON(useRoot, false)
+// runDirectScript runs the named scripts from package.json without showing the
+// menu, exiting with the first failing script's code.
+func runDirectScript(names []string, extra []string, useRoot bool, mode runMode) {
+	pkgPath := resolvePackageJSON(useRoot, false, nil, ui.Options{})
 	if pkgPath == "" {
 		fatal("%s", i18n.Get().ErrNoPackageJSON)
 	}
 
 	m := i18n.Get()
 
-	scripts, err := parser.Parse(pkgPath)
+	target := newRunTarget(pkgPath)
+	scripts, err := parseScripts(pkgPath, target.pm)
+	if err != nil {
+		fatal(m.ErrReadPackageJSON, filepath.Base(pkgPath), err)
+	}
+
+	var found []parser.Script
+	for _, name := range names {
+		i := parser.Lookup(scripts, name)
+		if i < 0 {
+			unknownScript(name, scripts)
+		}
+		found = append(found, scripts[i])
+	}
+
+	printContext(pkgPath, target.pm)
+	os.Exit(runScripts(found, extra, target, mode))
+}
+
+// unknownScript reports a script name missing from package.json, lists the
+// available ones and exits.
+func unknownScript(name string, scripts []parser.Script) {
+	m := i18n.Get()
+	fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Red, fmt.Sprintf(m.ErrUnknownScript, name), ansi.Reset)
+	fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Gray, m.AvailableScripts, ansi.Reset)
+	for _, s := range scripts {
+		desc := s.Description
+		if desc == "" {
+			desc = s.Command
+		}
+		fmt.Fprintf(os.Stderr, "  %s•%s %s  %s%s%s\n", ansi.Purple, ansi.Reset, s.Name, ansi.Gray, desc, ansi.Reset)
+	}
+	os.Exit(1)
+}
+
+// runAgain replays a history entry: the Nth one shown by --history when a
+// number is given, otherwise the most recent run in the current project.
+// Extra arguments replace the recorded ones when provided.
+func runAgain(args, extra []string) {
+	m := i18n.Get()
+	hist, err := history.New()
 	if err != nil {
-		fatal(m.ErrReadPackageJSON, err)
+		fatal(m.ErrReadHistory, err)
+	}
+
+	var entry history.Entry
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			fatal(m.ErrNoHistoryEntry, args[0])
+		}
+		recent := hist.Recent(n)
+		if len(recent) < n {
+			fatal(m.ErrNoHistoryEntry, args[0])
+		}
+		entry = recent[n-1]
+	} else {
+		dir, err := os.Getwd()
+		if err != nil {
+			fatal(m.ErrGeneric, err)
+		}
+		root := dir
+		if rootPkg := parser.FindRootPackageJSON(dir); rootPkg != "" {
+			root = filepath.Dir(rootPkg)
+		} else if project := parser.FindProject(dir); project != "" {
+			root = filepath.Dir(project)
+		}
+		var ok bool
+		if entry, ok = hist.LastInProject(root); !ok {
+			fatal("%s", m.ErrNothingToReplay)
+		}
+	}
+
+	if len(extra) == 0 {
+		extra = entry.Args
+	}
+	replayEntry(entry, extra)
+}
+
+// replayEntry runs a history entry's script again in the package it ran in.
+func replayEntry(e history.Entry, extra []string) {
+	m := i18n.Get()
+
+	pkgPath := e.PackageJSON
+	if pkgPath == "" {
+		pkgPath = parser.FindProject(e.Directory)
+	}
+	if pkgPath == "" {
+		fatal("%s", m.ErrNoPackageJSON)
 	}
 
+	if e.Directory != "" {
+		_ = os.Chdir(e.Directory)
+	}
+	target := newRunTarget(pkgPath)
+	scripts, err := parseScripts(pkgPath, target.pm)
+	if err != nil {
+		fatal(m.ErrReadPackageJSON, filepath.Base(pkgPath), err)
+	}
+	// When several sources define the script, prefer the one that ran.
 	var found *parser.Script
 	for _, s := range scripts {
-		if s.Name == script {
+		if s.Name != e.Script {
+			continue
+		}
+		if found == nil {
+			found = &s
+		}
+		if tool, ok := detector.ForTool(s.Source); ok && tool.Name == e.Runner {
 			found = &s
 			break
 		}
 	}
-
 	if found == nil {
-		fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Red, fmt.Sprintf(m.ErrUnknownScript, script), ansi.Reset)
-		fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Gray, m.AvailableScripts, ansi.Reset)
-		for _, s := range scripts {
-			desc := s.Description
-			if desc == "" {
-				desc = s.Command
-			}
-			fmt.Fprintf(os.Stderr, "  %s•%s %s  %s%s%s\n", ansi.Purple, ansi.Reset, s.Name, ansi.Gray, desc, ansi.Reset)
-		}
-		os.Exit(1)
+		fatal(m.ErrUnknownScript, e.Script)
 	}
 
-	pkgDir := filepath.Dir(pkgPath)
-	pm := detector.Detect(pkgDir)
-	if pm.Manager == detector.NPM {
-		rootPkg := parser.FindRootPackageJSON(pkgDir)
-		if rootPkg != "" {
-			rootPM := detector.Detect(filepath.Dir(rootPkg))
-			if rootPM.Manager != detector.NPM {
-				pm = rootPM
-			}
-		}
+	printContext(pkgPath, target.pm)
+	if !confirmRun([]parser.Script{*found}) {
+		os.Exit(1)
 	}
-	printContext(pkgPath, pm)
-	executeScript(found.Name, found.Command, pm)
+	os.Exit(executeScript(*found, extra, target, false))
 }
 
 func findPackageJSON() string {
@@ -503,17 +802,69 @@ func findPackageJSON() string {
 	if err != nil {
 		return ""
 	}
-	return parser.FindPackageJSON(dir)
+	return parser.FindProject(dir)
 }
 
-func showHistory() {
+// showHistory opens the interactive history browser, where Enter re-runs an
+// entry in its original directory and Delete removes it once confirmed. A
+// static list is printed when stdin is not a terminal.
+func showHistory(opts ui.Options) {
 	m := i18n.Get()
 	hist, err := history.New()
 	if err != nil {
 		fatal(m.ErrReadHistory, err)
 	}
 
-	entries := hist.Recent(20)
+	if !ui.IsTerminal() {
+		printHistory(hist.Recent(20))
+		return
+	}
+
+	entries := hist.Recent(history.MaxEntries)
+	if len(entries) == 0 {
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.HistoryEmpty, ansi.Reset)
+		return
+	}
+
+	// Entries are numbered as for skit --again N.
+	items := make([]ui.Item, len(entries))
+	for i, e := range entries {
+		detail := e.Runner + "  " + formatAge(e.Timestamp) + "  "
+		if e.Workspace != "" {
+			detail += e.Workspace + "  "
+		}
+		items[i] = ui.Item{
+			Name:   e.Script,
+			Prefix: fmt.Sprintf("%s%2d.%s  %s", ansi.Purple, i+1, ansi.Reset, formatStatus(e)),
+			Detail: detail + e.Directory,
+			Search: []string{e.Directory, e.Runner, e.Workspace},
+		}
+	}
+
+	idx, ok := ui.Select(ui.List{
+		Title:          m.HistoryTitle,
+		Items:          items,
+		Hint:           m.HistoryHint,
+		CountFmt:       m.HistoryCount,
+		Empty:          m.NoMatchingEntries,
+		FallbackPrompt: m.HistoryPrompt,
+		RemovePrompt:   m.HistoryRemove,
+		OnRemove: func(index int) bool {
+			return hist.Remove(entries[index]) == nil
+		},
+	}, opts)
+	if !ok {
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
+		return
+	}
+
+	e := entries[idx]
+	replayEntry(e, e.Args)
+}
+
+// printHistory prints history entries as a numbered list.
+func printHistory(entries []history.Entry) {
+	m := i18n.Get()
 	if len(entries) == 0 {
 		fmt.Printf("%s%s%s\n", ansi.Gray, m.HistoryEmpty, ansi.Reset)
 		return
@@ -522,16 +873,46 @@ func showHistory() {
 	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Purple, m.HistoryTitle, ansi.Reset)
 	for i, e := range entries {
 		age := formatAge(e.Timestamp)
-		fmt.Printf("  %s%2d.%s  %s%-20s%s  %s%s%s  %s%s  %s%s\n",
+		dir := e.Directory
+		if e.Workspace != "" {
+			dir = e.Workspace + "  " + dir
+		}
+		fmt.Printf("  %s%2d.%s  %s  %s%-20s%s  %s%s%s  %s%s  %s%s\n",
 			ansi.Purple, i+1, ansi.Reset,
+			formatStatus(e),
 			ansi.Bold, e.Script, ansi.Reset,
 			ansi.Cyan, e.Runner, ansi.Reset,
 			ansi.Gray, age,
-			e.Directory, ansi.Reset,
+			dir, ansi.Reset,
 		)
 	}
 }
 
+// formatStatus renders the outcome of a history entry as a fixed-width
+// "✓ 1.2s" / "✗ 2 4.1s" column; entries without a result are left blank.
+func formatStatus(e history.Entry) string {
+	if !e.Completed() {
+		return fmt.Sprintf("%-12s", "")
+	}
+	if e.ExitCode == 0 {
+		return fmt.Sprintf("%s%-12s%s", ansi.Green, "✓ "+formatDuration(e.Duration), ansi.Reset)
+	}
+	return fmt.Sprintf("%s%-12s%s", ansi.Red, fmt.Sprintf("✗ %d %s", e.ExitCode, formatDuration(e.Duration)), ansi.Reset)
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
 func formatAge(t time.Time) string {
 	m := i18n.Get()
 	d := time.Since(t)
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/subut0n/skit/internal/ansi"
+	"github.com/subut0n/skit/internal/detector"
+	"github.com/subut0n/skit/internal/history"
+	"github.com/subut0n/skit/internal/i18n"
+	"github.com/subut0n/skit/internal/parser"
+	"github.com/subut0n/skit/internal/runner"
+)
+
+// runTarget describes the package a script runs in.
+type runTarget struct {
+	pkgPath   string        // absolute path to the package.json
+	workspace string        // workspace package name, empty for a standalone or root package
+	pm        detector.Info // runner detected for the package
+}
+
+// newRunTarget resolves the workspace and package manager for a package.json.
+func newRunTarget(pkgPath string) runTarget {
+	if abs, err := filepath.Abs(pkgPath); err == nil {
+		pkgPath = abs
+	}
+	t := runTarget{pkgPath: pkgPath, pm: detectRunner(pkgPath)}
+
+	rootPkg := parser.FindRootPackageJSON(filepath.Dir(pkgPath))
+	if rootPkg != "" && filepath.Dir(rootPkg) != filepath.Dir(pkgPath) {
+		t.workspace = parser.ParseName(pkgPath)
+		if t.workspace == "" {
+			t.workspace, _ = filepath.Rel(filepath.Dir(rootPkg), filepath.Dir(pkgPath))
+		}
+	}
+	return t
+}
+
+// forScript returns the target a script runs in: its own package when it
+// comes from another package.json, as in the combined monorepo view.
+func (t runTarget) forScript(s parser.Script) runTarget {
+	if s.PkgPath == "" || s.PkgPath == t.pkgPath {
+		return t
+	}
+	return newRunTarget(s.PkgPath)
+}
+
+// scriptLabel names a script for output shared with other scripts, with its
+// package when it has one.
+func scriptLabel(s parser.Script) string {
+	if s.Workspace != "" {
+		return s.Workspace + " › " + s.Name
+	}
+	return s.Name
+}
+
+// detectRunner detects the package manager of a package.json, looking at the
+// monorepo root too when the package is part of one. Other project files run
+// with their own tool.
+func detectRunner(pkgPath string) detector.Info {
+	src := parser.SourceName(pkgPath)
+	if src == "deno" {
+		return detector.DetectDeno(pkgPath)
+	}
+	if tool, ok := detector.ForTool(src); ok {
+		tool.Evidence = []detector.Evidence{{Path: pkgPath, Name: tool.Name}}
+		return tool
+	}
+	pkgDir := filepath.Dir(pkgPath)
+	rootDir := ""
+	if rootPkg := parser.FindRootPackageJSON(pkgDir); rootPkg != "" {
+		rootDir = filepath.Dir(rootPkg)
+	}
+	return detector.DetectFrom(pkgDir, rootDir)
+}
+
+// parseScripts reads the scripts of a project file, with the pre and post
+// hooks linked to their script when the runner runs them.
+func parseScripts(pkgPath string, pm detector.Info) ([]parser.Script, error) {
+	scripts, err := parser.Parse(pkgPath)
+	if err == nil && pm.RunsHooks() {
+		parser.AttachHooks(scripts)
+	}
+	return scripts, err
+}
+
+// executeScript runs a script via the detected package manager, forwarding
+// extra arguments to it, records the run in the history and returns the
+// script's exit code. With noHooks, its pre and post hooks are skipped where
+// the package manager allows it.
+func executeScript(s parser.Script, extra []string, t runTarget, noHooks bool) int {
+	m := i18n.Get()
+
+	cmd := scriptCommand(s, extra, t, noHooks)
+	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.Executing, cmd.Args[0], strings.Join(cmd.Args[1:], " ")), ansi.Reset)
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	start := time.Now()
+	err := runner.Run(cmd)
+	code := runner.ExitCode(err)
+	recordRun(s, extra, t, cmd, start, code, time.Since(start))
+
+	if err != nil {
+		reportFailure(s.Name, err, code)
+		return code
+	}
+
+	fmt.Printf("\n%s%s%s%s\n", ansi.Bold, ansi.Green, m.Success, ansi.Reset)
+	return 0
+}
+
+// runMode says how several scripts selected together are run.
+type runMode struct {
+	parallel   bool
+	limit      int      // maximum number of scripts running at once, 0 for no limit
+	killOthers bool     // stop the other scripts when one fails (parallel only)
+	noHooks    bool     // skip the pre and post hooks of package.json scripts
+	palette    []string // colors for the output prefixes
+}
+
+func (m runMode) options() runner.Options {
+	return runner.Options{KillOthersOnFail: m.killOthers, Limit: m.limit}
+}
+
+// runScripts runs several scripts one after the other, stopping at the first
+// failure, or all at once in parallel mode, once those asking for it are
+// confirmed. It returns the exit code of the first script that failed, or 1
+// when a confirmation is declined.
+func runScripts(scripts []parser.Script, extra []string, t runTarget, mode runMode) int {
+	if !confirmRun(scripts) {
+		return 1
+	}
+	if mode.parallel && len(scripts) > 1 {
+		return runParallel(scripts, extra, t, mode)
+	}
+	for i, s := range scripts {
+		if i > 0 {
+			fmt.Println()
+		}
+		st := t.forScript(s)
+		if st.pkgPath != t.pkgPath {
+			printContext(st.pkgPath, st.pm)
+		}
+		if code := executeScript(s, extra, st, mode.noHooks); code != 0 {
+			return code
+		}
+	}
+	return 0
+}
+
+// runParallel runs scripts concurrently, each output line prefixed with the
+// name of the script that printed it, then prints a summary of the runs.
+func runParallel(scripts []parser.Script, extra []string, t runTarget, mode runMode) int {
+	m := i18n.Get()
+
+	names := make([]string, len(scripts))
+	width := 0
+	for i, s := range scripts {
+		names[i] = scriptLabel(s)
+		width = max(width, utf8.RuneCountInString(names[i]))
+	}
+	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.ExecutingParallel, strings.Join(names, ", ")), ansi.Reset)
+
+	jobs := make([]runner.Job, len(scripts))
+	for i, s := range scripts {
+		jobs[i] = runner.Job{
+			Name:   names[i],
+			Prefix: jobPrefix(names[i], width, mode.palette[i%len(mode.palette)]),
+			Cmd:    scriptCommand(s, extra, t.forScript(s), mode.noHooks),
+		}
+	}
+
+	start := time.Now()
+	results := runner.RunAll(jobs, os.Stdout, mode.options())
+
+	for i, r := range results {
+		if !r.Skipped {
+			recordRun(scripts[i], extra, t.forScript(scripts[i]), jobs[i].Cmd, start, r.ExitCode, r.Duration)
+		}
+	}
+	return printSummary(results, width, mode.palette)
+}
+
+// jobPrefix returns the prefix of a parallel job's output lines: its name in
+// color, padded to width.
+func jobPrefix(name string, width int, color string) string {
+	return fmt.Sprintf("%s%-*s%s %s│%s ", color, width, name, ansi.Reset, ansi.Gray, ansi.Reset)
+}
+
+// printSummary prints one line per parallel run with its status, exit code
+// and duration, and returns the exit code of the first script that failed on
+// its own, before any that were stopped because of it.
+func printSummary(results []runner.Result, width int, palette []string) int {
+	m := i18n.Get()
+
+	fmt.Printf("\n%s%s%s\n", ansi.Bold, m.ParallelSummary, ansi.Reset)
+	failed, skipped, first := 0, 0, -1
+	for i, r := range results {
+		mark, note := ansi.Green+"✓", ""
+		switch {
+		case r.Skipped:
+			color := palette[i%len(palette)]
+			fmt.Printf("  %s–%s %s%-*s%s    -  %s%s%s\n", ansi.Gray, ansi.Reset, color, width, r.Name, ansi.Reset, ansi.Gray, m.ParallelSkipped, ansi.Reset)
+			skipped++
+			continue
+		case r.Killed:
+			mark, note = ansi.Yellow+"■", "  "+ansi.Gray+m.ParallelKilled+ansi.Reset
+		case r.Err != nil:
+			mark = ansi.Red + "✗"
+		}
+		color := palette[i%len(palette)]
+		fmt.Printf("  %s%s %s%-*s%s  %3d  %s%s\n", mark, ansi.Reset, color, width, r.Name, ansi.Reset,
+			r.ExitCode, formatDuration(r.Duration), note)
+
+		if r.Err == nil {
+			continue
+		}
+		failed++
+		if first < 0 || (!r.Killed && results[first].Killed) {
+			first = i
+		}
+	}
+
+	for _, r := range results {
+		var exitErr *exec.ExitError
+		if r.Err != nil && !r.Killed && !errors.As(r.Err, &exitErr) {
+			reportFailure(r.Name, r.Err, r.ExitCode)
+		}
+	}
+
+	if failed > 0 || skipped > 0 {
+		fmt.Fprintln(os.Stderr)
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Red, fmt.Sprintf(m.ParallelFailed, failed, len(results)), ansi.Reset)
+		}
+		if skipped > 0 {
+			fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Gray, fmt.Sprintf(m.ParallelNotRun, skipped, len(results)), ansi.Reset)
+		}
+		if first < 0 {
+			return 1
+		}
+		return results[first].ExitCode
+	}
+	fmt.Printf("\n%s%s%s%s\n", ansi.Bold, ansi.Green, m.Success, ansi.Reset)
+	return 0
+}
+
+// runner returns what runs a script of the target package: the tool of its
+// source for scripts that are not package.json scripts, the package manager
+// otherwise.
+func (t runTarget) runner(s parser.Script) detector.Info {
+	if tool, ok := detector.ForTool(s.Source); ok {
+		return tool
+	}
+	return t.pm
+}
+
+// scriptCommand builds the runner command for a script in the target package.
+// Yarn Berry runs workspace scripts from the monorepo root with
+// "yarn workspace", so that the root's yarnPath and install state apply.
+// With noHooks, the command skips the script's pre and post hooks, with a
+// warning when the package manager cannot. The x-skit arguments come before
+// the forwarded ones, and its env and cwd apply to the runner. A cwd that is
+// not a directory inside the package makes the command fail to start.
+func scriptCommand(s parser.Script, extra []string, t runTarget, noHooks bool) *exec.Cmd {
+	pm := t.runner(s)
+	extra = append(slices.Clip(s.Args), extra...)
+	pkgDir := filepath.Dir(t.pkgPath)
+	dir := pkgDir
+
+	args := pm.RunArgs(s.Name, extra)
+	if noHooks && (s.Pre != "" || s.Post != "") {
+		var ok bool
+		if args, ok = pm.NoHooksRunArgs(s.Name, extra); !ok {
+			fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Yellow, fmt.Sprintf(i18n.Get().HooksNotSkipped, pm.Name, s.Name), ansi.Reset)
+		}
+	}
+	if pm.Manager == detector.YarnBerry && t.workspace != "" {
+		if rootPkg := parser.FindRootPackageJSON(filepath.Dir(t.pkgPath)); rootPkg != "" && parser.ParseName(t.pkgPath) == t.workspace {
+			args = pm.WorkspaceRunArgs(t.workspace, s.Name, extra)
+			dir = filepath.Dir(rootPkg)
+		}
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	if s.Cwd != "" {
+		cmd.Dir = filepath.Join(pkgDir, s.Cwd)
+		if info, err := os.Stat(cmd.Dir); !filepath.IsLocal(s.Cwd) || err != nil || !info.IsDir() {
+			cmd.Err = fmt.Errorf(i18n.Get().ErrCwdOutside, s.Cwd, scriptLabel(s))
+		}
+	}
+	if len(s.Env) > 0 {
+		cmd.Env = os.Environ()
+		for _, k := range slices.Sorted(maps.Keys(s.Env)) {
+			cmd.Env = append(cmd.Env, k+"="+s.Env[k])
+		}
+	}
+	return cmd
+}
+
+// confirmRun asks before running the scripts marked confirm in x-skit, and
+// reports whether every one was accepted. Anything but a yes, including no
+// answer when stdin is closed, declines.
+func confirmRun(scripts []parser.Script) bool {
+	m := i18n.Get()
+	reader := bufio.NewReader(os.Stdin)
+	for _, s := range scripts {
+		if !s.Confirm {
+			continue
+		}
+		fmt.Printf("%s%s%s", ansi.Yellow, fmt.Sprintf(m.ConfirmRun, scriptLabel(s)), ansi.Reset)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer == "" || !slices.Contains(strings.Fields(m.ConfirmYes), answer) {
+			fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
+			return false
+		}
+	}
+	return true
+}
+
+// recordRun adds a finished run to the history.
+func recordRun(s parser.Script, extra []string, t runTarget, cmd *exec.Cmd, start time.Time, code int, d time.Duration) {
+	hist, err := history.New()
+	if err != nil {
+		return
+	}
+	_ = hist.Record(history.Entry{
+		Script:      s.Name,
+		Command:     s.Command,
+		Runner:      t.runner(s).Name,
+		Timestamp:   start,
+		Args:        extra,
+		RunCommand:  strings.Join(cmd.Args, " "),
+		PackageJSON: t.pkgPath,
+		Workspace:   t.workspace,
+		ExitCode:    code,
+		Duration:    d,
+	})
+}
+
+// reportFailure prints why a script failed: its exit code when it ran, or
+// the error that prevented it from starting.
+func reportFailure(name string, err error, code int) {
+	m := i18n.Get()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Red, fmt.Sprintf(m.ErrScriptExited, name, code), ansi.Reset)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s%s%s\n", ansi.Red, fmt.Sprintf(m.ErrCommandFailed, err), ansi.Reset)
+	}
+}
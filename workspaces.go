@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/subut0n/skit/internal/ansi"
+	"github.com/subut0n/skit/internal/config"
+	"github.com/subut0n/skit/internal/git"
+	"github.com/subut0n/skit/internal/i18n"
+	"github.com/subut0n/skit/internal/parser"
+	"github.com/subut0n/skit/internal/runner"
+	"github.com/subut0n/skit/internal/ui"
+	"github.com/subut0n/skit/internal/workspace"
+)
+
+// monorepo is the root package.json of a monorepo with its workspace
+// packages and their scripts.
+type monorepo struct {
+	rootPkg  string
+	graph    *workspace.Graph
+	packages []int             // graph indexes of the packages to work with
+	scripts  [][]parser.Script // scripts of each package, by graph index
+}
+
+// loadMonorepo reads the monorepo containing the current directory, keeping
+// the packages matched by the filters, or all of them when there is none, and
+// only those affected by git changes since changedSince when changed is set,
+// the default branch when it is empty. It exits when there is no monorepo or
+// no package left.
+func loadMonorepo(filters []string, changed bool, changedSince string) monorepo {
+	m := i18n.Get()
+	dir, err := os.Getwd()
+	if err != nil {
+		fatal(m.ErrGeneric, err)
+	}
+	rootPkg := parser.FindRootPackageJSON(dir)
+	if rootPkg == "" {
+		fatal("%s", m.ErrNoPackageJSON)
+	}
+	packages := parser.ParseWorkspaces(rootPkg)
+	if len(packages) == 0 {
+		fatal("%s", m.ErrNoWorkspaces)
+	}
+
+	repo := monorepo{rootPkg: rootPkg, graph: workspace.NewGraph(packages)}
+	if len(filters) > 0 {
+		repo.packages = selectPackages(repo.graph, filters)
+	} else {
+		for i := range packages {
+			repo.packages = append(repo.packages, i)
+		}
+	}
+	if changed {
+		if changedSince == "" {
+			changedSince = git.DefaultBranch(filepath.Dir(rootPkg))
+		}
+		affected := changedPackages(repo.graph, filepath.Dir(rootPkg), changedSince)
+		repo.packages = slices.DeleteFunc(repo.packages, func(i int) bool {
+			return !slices.Contains(affected, i)
+		})
+		if len(repo.packages) == 0 {
+			fmt.Printf("%s%s%s\n", ansi.Gray, fmt.Sprintf(m.NoChangedPackages, changedSince), ansi.Reset)
+			os.Exit(0)
+		}
+		var names []string
+		for _, i := range repo.packages {
+			names = append(names, packages[i].Name)
+		}
+		fmt.Printf("%s%s%s\n", ansi.Gray, fmt.Sprintf(m.ChangedPackages, changedSince, strings.Join(names, ", ")), ansi.Reset)
+	}
+	repo.scripts = make([][]parser.Script, len(packages))
+	for _, i := range repo.packages {
+		repo.scripts[i], _ = parseScripts(packages[i].PkgPath, detectRunner(packages[i].PkgPath))
+	}
+	return repo
+}
+
+// selectPackages resolves --filter selectors, exiting when they are invalid
+// or match no package.
+func selectPackages(g *workspace.Graph, filters []string) []int {
+	m := i18n.Get()
+	selected, err := g.Select(filters)
+	if err != nil {
+		fatal(m.ErrGeneric, err)
+	}
+	if len(selected) == 0 {
+		fatal(m.ErrNoFilterMatch, strings.Join(filters, " "))
+	}
+	return selected
+}
+
+// changedPackages returns the packages with files changed since ref in the
+// git repository, and those depending on them.
+func changedPackages(g *workspace.Graph, rootDir, ref string) []int {
+	m := i18n.Get()
+	files, err := git.ChangedFiles(rootDir, ref)
+	if err != nil {
+		fatal(m.ErrGeneric, err)
+	}
+	// git reports paths with symlinks resolved.
+	if resolved, err := filepath.EvalSymlinks(rootDir); err == nil {
+		rootDir = resolved
+	}
+	var rel []string
+	for _, f := range files {
+		if r, err := filepath.Rel(rootDir, f); err == nil && !strings.HasPrefix(r, "..") {
+			rel = append(rel, r)
+		}
+	}
+	return g.Affected(rel)
+}
+
+// defining returns the selected packages that define the named script.
+func (r monorepo) defining(name string) []int {
+	var indexes []int
+	for _, i := range r.packages {
+		if parser.Lookup(r.scripts[i], name) >= 0 {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// runAllWorkspaces runs the scripts named on the command line in every
+// selected workspace package defining them, or lets the user pick among the
+// scripts of those packages when none is named.
+func runAllWorkspaces(cli cliArgs, cfg *config.Manager) {
+	m := i18n.Get()
+	repo := loadMonorepo(cli.filters, cli.changed, cli.changedSince)
+	printContext(repo.rootPkg, detectRunner(repo.rootPkg))
+
+	names := cli.args
+	if len(names) == 0 {
+		result := ui.Run(repo.allScripts(), menuOptions(cfg))
+		if !result.Confirmed || result.Script == nil {
+			fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
+			return
+		}
+		for _, s := range result.Scripts {
+			names = append(names, s.Name)
+		}
+	}
+
+	mode := cli.runMode(cfg)
+	if !mode.parallel {
+		mode.limit = 1
+	}
+	for i, name := range names {
+		if len(repo.defining(name)) == 0 {
+			fatal(m.ErrNoWorkspaceRun, name)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		if code := repo.run(name, cli.extra, mode); code != 0 {
+			os.Exit(code)
+		}
+	}
+}
+
+// runMonorepoMenu shows the scripts of the root package.json and of every
+// selected workspace package in one menu, grouped by package, and runs the
+// chosen ones in their own package.
+func runMonorepoMenu(cli cliArgs, cfg *config.Manager) {
+	m := i18n.Get()
+	repo := loadMonorepo(cli.filters, cli.changed, cli.changedSince)
+
+	var scripts []parser.Script
+	if len(cli.filters) == 0 && !cli.changed {
+		rootName := parser.ParseName(repo.rootPkg)
+		if rootName == "" {
+			rootName = filepath.Base(filepath.Dir(repo.rootPkg))
+		}
+		rootScripts, _ := parseScripts(repo.rootPkg, detectRunner(repo.rootPkg))
+		scripts = append(scripts, inPackage(rootScripts, rootName, repo.rootPkg)...)
+	}
+	for _, i := range repo.packages {
+		pkg := repo.graph.Packages[i]
+		scripts = append(scripts, inPackage(repo.scripts[i], pkg.Name, pkg.PkgPath)...)
+	}
+	if len(scripts) == 0 {
+		fatal("%s", m.ErrNoScripts)
+	}
+
+	target := newRunTarget(repo.rootPkg)
+	printContext(repo.rootPkg, target.pm)
+
+	result := ui.Run(scripts, menuOptions(cfg))
+	if !result.Confirmed || result.Script == nil {
+		fmt.Printf("%s%s%s\n", ansi.Gray, m.Cancelled, ansi.Reset)
+		return
+	}
+	os.Exit(runScripts(result.Scripts, cli.extra, target, cli.runMode(cfg)))
+}
+
+// inPackage tags scripts with the package they belong to.
+func inPackage(scripts []parser.Script, name, pkgPath string) []parser.Script {
+	tagged := make([]parser.Script, len(scripts))
+	for i, s := range scripts {
+		s.Workspace, s.PkgPath = name, pkgPath
+		tagged[i] = s
+	}
+	return tagged
+}
+
+// allScripts returns one entry per script name found in any package,
+// described by the number of packages defining it.
+func (r monorepo) allScripts() []parser.Script {
+	m := i18n.Get()
+	count := make(map[string]int)
+	var scripts []parser.Script
+	for _, pkgScripts := range r.scripts {
+		for _, s := range pkgScripts {
+			if count[s.Name] == 0 {
+				scripts = append(scripts, s)
+			}
+			count[s.Name]++
+		}
+	}
+	for i := range scripts {
+		scripts[i].Description = fmt.Sprintf(m.WorkspaceCount, count[scripts[i].Name])
+	}
+	// Same order as parser.Parse: ungrouped first, then by group and name.
+	slices.SortFunc(scripts, func(a, b parser.Script) int {
+		if c := strings.Compare(a.Group, b.Group); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return scripts
+}
+
+// run runs a script in every package defining it, each package waiting for
+// the packages it depends on, and prints a summary. It returns the exit code
+// of the first package that failed, or 1 when the confirmation is declined.
+func (r monorepo) run(name string, extra []string, mode runMode) int {
+	m := i18n.Get()
+	order := r.graph.Order(r.defining(name))
+	position := make(map[int]int, len(order))
+	width := 0
+	for k, i := range order {
+		position[i] = k
+		width = max(width, utf8.RuneCountInString(r.graph.Packages[i].Name))
+	}
+
+	// One confirmation covers every package.
+	for _, i := range order {
+		if s := r.scripts[i][parser.Lookup(r.scripts[i], name)]; s.Confirm {
+			if !confirmRun([]parser.Script{s}) {
+				return 1
+			}
+			break
+		}
+	}
+
+	fmt.Printf("%s%s%s%s\n\n", ansi.Bold, ansi.Green, fmt.Sprintf(m.ExecutingAll, name, len(order)), ansi.Reset)
+
+	jobs := make([]runner.Job, len(order))
+	targets := make([]runTarget, len(order))
+	scripts := make([]parser.Script, len(order))
+	for k, i := range order {
+		pkg := r.graph.Packages[i]
+		scripts[k] = r.scripts[i][parser.Lookup(r.scripts[i], name)]
+		targets[k] = newRunTarget(pkg.PkgPath)
+
+		var after []int
+		for _, d := range r.graph.Dependencies(i) {
+			if p, ok := position[d]; ok && p < k {
+				after = append(after, p)
+			}
+		}
+		jobs[k] = runner.Job{
+			Name:   pkg.Name,
+			Prefix: jobPrefix(pkg.Name, width, mode.palette[k%len(mode.palette)]),
+			Cmd:    scriptCommand(scripts[k], extra, targets[k], mode.noHooks),
+			After:  after,
+		}
+	}
+
+	start := time.Now()
+	results := runner.RunAll(jobs, os.Stdout, mode.options())
+	for k, res := range results {
+		if !res.Skipped {
+			recordRun(scripts[k], extra, targets[k], jobs[k].Cmd, start, res.ExitCode, res.Duration)
+		}
+	}
+	return printSummary(results, width, mode.palette)
+}